@@ -0,0 +1,187 @@
+package sensironsgp30
+
+import (
+	"context"
+	"time"
+
+	coreio "github.com/go-sensors/core/io"
+	"github.com/pkg/errors"
+)
+
+// BaselineStore persists baseline values so that they survive restarts of the sensor
+type BaselineStore interface {
+	// Load gets the most recently saved baseline for the sensor with the given serial ID, or nil if no usable baseline is available
+	Load(ctx context.Context, serialID uint64) (*Baseline, error)
+	// Save records the given baseline for the sensor with the given serial ID, replacing any previously saved baseline
+	Save(ctx context.Context, serialID uint64, baseline *Baseline) error
+}
+
+// WithBaselineStore specifies a store used to restore the baseline after the sensor is initialized and to persist each periodic snapshot of it once the early operation phase has ended
+func WithBaselineStore(store BaselineStore) *Option {
+	return &Option{
+		apply: func(s *Sensor) {
+			s.baselineStore = store
+		},
+	}
+}
+
+// BaselineStore is the store used to restore and persist the baseline
+func (s *Sensor) BaselineStore() BaselineStore {
+	return s.baselineStore
+}
+
+// BaselineHandler is a function that receives periodic snapshots of the sensor's baseline
+type BaselineHandler func(*Baseline)
+
+// WithBaselineHandler registers a function that will be called with each periodic snapshot of the baseline
+func WithBaselineHandler(f BaselineHandler) *Option {
+	return &Option{
+		apply: func(s *Sensor) {
+			s.baselineHandlerFunc = f
+		},
+	}
+}
+
+// BaselineHandler is the function that will be called with each periodic snapshot of the baseline
+func (s *Sensor) BaselineHandler() BaselineHandler {
+	return s.baselineHandlerFunc
+}
+
+// WithBaselineInterval specifies the duration between periodic snapshots of the baseline; a non-positive interval selects DefaultBaselineInterval
+func WithBaselineInterval(interval time.Duration) *Option {
+	return &Option{
+		apply: func(s *Sensor) {
+			if interval <= 0 {
+				interval = DefaultBaselineInterval
+			}
+			s.baselineInterval = interval
+		},
+	}
+}
+
+// BaselineInterval is the duration between periodic snapshots of the baseline
+func (s *Sensor) BaselineInterval() time.Duration {
+	return s.baselineInterval
+}
+
+func restoreBaseline(ctx context.Context, port coreio.Port, store BaselineStore, serialID uint64) (bool, error) {
+	baseline, err := store.Load(ctx, serialID)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to load baseline")
+	}
+	if baseline == nil {
+		return false, nil
+	}
+
+	err = setBaseline(ctx, port, baseline)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to set baseline")
+	}
+	return true, nil
+}
+
+// liveBaseline is the baseline most recently read from or written to the connected sensor
+type liveBaseline struct {
+	baseline   *Baseline
+	serialID   uint64
+	capturedAt time.Time
+	restored   bool
+}
+
+// rememberBaseline records the given baseline so that it can be replayed after the sensor is next initialized
+func (s *Sensor) rememberBaseline(baseline *Baseline) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.liveBaseline = &liveBaseline{
+		baseline:   baseline,
+		serialID:   s.serialID,
+		capturedAt: time.Now(),
+		restored:   !s.status().EarlyOperationPhase,
+	}
+}
+
+// replayBaseline writes the remembered baseline to the sensor with the given serial ID, unless it belongs to another sensor or has expired
+func (s *Sensor) replayBaseline(ctx context.Context, port coreio.Port, serialID uint64) (bool, error) {
+	s.mu.Lock()
+	live := s.liveBaseline
+	s.mu.Unlock()
+	if live == nil || live.serialID != serialID || time.Since(live.capturedAt) > MaxBaselineAge {
+		return false, nil
+	}
+
+	err := setBaseline(ctx, port, live.baseline)
+	if err != nil {
+		return false, err
+	}
+	if live.restored {
+		s.markBaselineRestored()
+	}
+	return true, nil
+}
+
+// terminatedError is an error that the RecoverableErrorHandler has already chosen to terminate Run with
+type terminatedError struct {
+	error
+}
+
+// handleBaseline hands each periodic snapshot of the baseline to the handler and the store
+//
+// A failure to save the baseline is reported to the RecoverableErrorHandler without interrupting the connection to the
+// sensor, since it does not affect communication with the sensor, unless the handler chooses to terminate Run.
+func (s *Sensor) handleBaseline(ctx context.Context, serialID uint64) func(*Baseline) error {
+	return func(baseline *Baseline) error {
+		if s.baselineHandlerFunc != nil {
+			s.baselineHandlerFunc(baseline)
+		}
+
+		// The vendor advises against persisting a baseline learned during the early operation phase
+		if s.baselineStore != nil && !s.Status().EarlyOperationPhase {
+			err := s.baselineStore.Save(ctx, serialID, baseline)
+			if err != nil && s.errorHandlerFunc != nil {
+				err = errors.Wrap(err, "failed to save baseline")
+				if s.errorHandlerFunc(err) {
+					return &terminatedError{err}
+				}
+			}
+		}
+		return nil
+	}
+}
+
+func requestBaselineRepeatedly(
+	ctx context.Context,
+	commands chan interface{},
+	interval time.Duration,
+	handle func(*Baseline) error) func() error {
+	return func() error {
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(interval):
+			}
+
+			command := &getBaselineRequest{request: newRequest()}
+			select {
+			case <-ctx.Done():
+				return nil
+			case commands <- command:
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case err := <-command.done:
+				if err != nil {
+					return nil
+				}
+			}
+
+			err := handle(command.baseline)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -3,6 +3,7 @@ package sensironsgp30
 
 import (
 	"context"
+	"math"
 	"time"
 
 	coreio "github.com/go-sensors/core/io"
@@ -24,7 +25,7 @@ var (
 )
 
 func initAirQuality(ctx context.Context, port coreio.Port) error {
-	_, err := port.Write([]byte{0x20, 0x03})
+	err := writeCommand(port, 0x2003)
 	if err != nil {
 		return err
 	}
@@ -37,12 +38,24 @@ func initAirQuality(ctx context.Context, port coreio.Port) error {
 	return nil
 }
 
-func setHumidity(ctx context.Context, port coreio.Port, absoluteHumidity units.MassConcentration) error {
-	fixedPointValue := uint16(absoluteHumidity.GramsPerCubicMeter() * 256)
-	humidityData := []byte{byte(fixedPointValue >> 8), byte(fixedPointValue)}
-	humidityCRC := crc8.Checksum(humidityData, checksumTable)
+// encodeAbsoluteHumidity converts an absolute humidity to the sensor's 8.8 fixed-point format in g/m³, rejecting values
+// that cannot be represented or that would be indistinguishable from zero, which disables humidity compensation
+func encodeAbsoluteHumidity(absoluteHumidity units.MassConcentration) (uint16, error) {
+	gramsPerCubicMeter := absoluteHumidity.GramsPerCubicMeter()
+	if math.IsNaN(gramsPerCubicMeter) || math.IsInf(gramsPerCubicMeter, 0) {
+		return 0, errors.Wrapf(ErrInvalidHumidity, "%v g/m³ is not a number", gramsPerCubicMeter)
+	}
 
-	_, err := port.Write([]byte{0x20, 0x61, humidityData[0], humidityData[1], humidityCRC})
+	fixedPointValue := math.Round(gramsPerCubicMeter * 256)
+	if fixedPointValue < 1 || fixedPointValue > math.MaxUint16 {
+		return 0, errors.Wrapf(ErrInvalidHumidity, "%v g/m³ is outside the supported range of %v to %v g/m³",
+			gramsPerCubicMeter, 1.0/256, float64(math.MaxUint16)/256)
+	}
+	return uint16(fixedPointValue), nil
+}
+
+func setHumidity(ctx context.Context, port coreio.Port, fixedPointValue uint16) error {
+	err := writeCommand(port, 0x2061, fixedPointValue)
 	if err != nil {
 		return err
 	}
@@ -60,7 +73,7 @@ type airQuality struct {
 }
 
 func measureAirQuality(ctx context.Context, port coreio.Port) (*airQuality, error) {
-	_, err := port.Write([]byte{0x20, 0x08})
+	err := writeCommand(port, 0x2008)
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +84,7 @@ func measureAirQuality(ctx context.Context, port coreio.Port) (*airQuality, erro
 	case <-time.After(readValueTimeout):
 	}
 
-	data, err := readWords(port, 2)
+	data, err := readWords(port, 0x2008, 2)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to read air quality")
 	}
@@ -83,28 +96,254 @@ func measureAirQuality(ctx context.Context, port coreio.Port) (*airQuality, erro
 	return reading, nil
 }
 
-func readWords(port coreio.Port, words int) ([]uint16, error) {
+// RawSignals represents the raw H2 and ethanol signals from which the sensor derives its air quality signals
+type RawSignals struct {
+	H2      uint16
+	Ethanol uint16
+}
+
+func measureRawSignals(ctx context.Context, port coreio.Port) (*RawSignals, error) {
+	err := writeCommand(port, 0x2050)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, nil
+	case <-time.After(readRawSignalsTimeout):
+	}
+
+	data, err := readWords(port, 0x2050, 2)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read raw signals")
+	}
+
+	signals := &RawSignals{
+		H2:      data[0],
+		Ethanol: data[1],
+	}
+	return signals, nil
+}
+
+// Baseline represents the compensation values used by the sensor's on-chip baseline algorithm
+type Baseline struct {
+	CO2eq uint16
+	TVOC  uint16
+}
+
+func getBaseline(ctx context.Context, port coreio.Port) (*Baseline, error) {
+	err := writeCommand(port, 0x2015)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, nil
+	case <-time.After(readValueTimeout):
+	}
+
+	data, err := readWords(port, 0x2015, 2)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read baseline")
+	}
+
+	baseline := &Baseline{
+		CO2eq: data[0],
+		TVOC:  data[1],
+	}
+	return baseline, nil
+}
+
+func setBaseline(ctx context.Context, port coreio.Port, baseline *Baseline) error {
+	// The sensor expects the baseline words in the reverse order of how they are read
+	err := writeCommand(port, 0x201e, baseline.TVOC, baseline.CO2eq)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(setValueTimeout):
+	}
+	return nil
+}
+
+func getTVOCInceptiveBaseline(ctx context.Context, port coreio.Port) (*uint16, error) {
+	err := writeCommand(port, 0x20b3)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, nil
+	case <-time.After(readValueTimeout):
+	}
+
+	data, err := readWords(port, 0x20b3, 1)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read TVOC inceptive baseline")
+	}
+
+	return &data[0], nil
+}
+
+func setTVOCBaseline(ctx context.Context, port coreio.Port, baseline uint16) error {
+	err := writeCommand(port, 0x2077, baseline)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(setValueTimeout):
+	}
+	return nil
+}
+
+// FeatureSet describes the product type and version of the sensor, which determine the commands it supports
+type FeatureSet struct {
+	ProductType    uint8
+	ProductVersion uint8
+}
+
+func (f *FeatureSet) supports(minimumVersion uint8) bool {
+	return f.ProductVersion >= minimumVersion
+}
+
+func getFeatureSet(ctx context.Context, port coreio.Port) (*FeatureSet, error) {
+	err := writeCommand(port, 0x202f)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, nil
+	case <-time.After(readFeatureSetTimeout):
+	}
+
+	data, err := readWords(port, 0x202f, 1)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read feature set")
+	}
+
+	featureSet := &FeatureSet{
+		ProductType:    uint8(data[0] >> 12),
+		ProductVersion: uint8(data[0]),
+	}
+	return featureSet, nil
+}
+
+func getSerialID(ctx context.Context, port coreio.Port) (uint64, error) {
+	err := writeCommand(port, 0x3682)
+	if err != nil {
+		return 0, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return 0, nil
+	case <-time.After(readSerialIDTimeout):
+	}
+
+	data, err := readWords(port, 0x3682, 3)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read serial ID")
+	}
+
+	serialID := uint64(data[0])<<32 | uint64(data[1])<<16 | uint64(data[2])
+	return serialID, nil
+}
+
+func measureTest(ctx context.Context, port coreio.Port) error {
+	const expectedResult = 0xD400
+
+	err := writeCommand(port, 0x2032)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(measureTestTimeout):
+	}
+
+	data, err := readWords(port, 0x2032, 1)
+	if err != nil {
+		return errors.Wrap(err, "failed to read self test result")
+	}
+
+	if data[0] != expectedResult {
+		return errors.Wrapf(ErrSelfTestFailed, "expected %#04x but got %#04x", expectedResult, data[0])
+	}
+	return nil
+}
+
+const generalCallResetCommand = 0x06
+
+func generalCallReset(ctx context.Context, portFactory coreio.PortFactory) error {
+	port, err := portFactory.Open()
+	if err != nil {
+		return errors.Wrap(err, "failed to open general call port")
+	}
+	defer port.Close()
+
+	_, err = port.Write([]byte{generalCallResetCommand})
+	if err != nil {
+		return &BusError{Command: generalCallResetCommand, Err: err}
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(resetTimeout):
+	}
+	return nil
+}
+
+func writeCommand(port coreio.Port, command uint16, words ...uint16) error {
+	buf := []byte{byte(command >> 8), byte(command)}
+	for _, word := range words {
+		wordBytes := []byte{byte(word >> 8), byte(word)}
+		buf = append(buf, wordBytes...)
+		buf = append(buf, crc8.Checksum(wordBytes, checksumTable))
+	}
+
+	_, err := port.Write(buf)
+	if err != nil {
+		return &BusError{Command: command, Err: err}
+	}
+	return nil
+}
+
+func readWords(port coreio.Port, command uint16, words int) ([]uint16, error) {
 	const (
 		wordLength = 2
 		crcLength  = 1
 	)
 
 	buf := make([]byte, words*(wordLength+crcLength))
-	_, err := port.Read(buf)
+	n, err := port.Read(buf)
 	if err != nil {
-		return nil, err
+		return nil, &BusError{Command: command, Err: err}
+	}
+	if n < len(buf) {
+		return nil, &BusError{Command: command, Err: errors.Wrapf(ErrShortRead, "read %d of %d bytes", n, len(buf))}
 	}
 
 	data := []uint16{}
 	for idx := 0; idx < len(buf); idx += wordLength + crcLength {
 		wordBytes := buf[idx : idx+2]
+		word := uint16(wordBytes[0])<<8 | uint16(wordBytes[1])
 		expectedCrc := buf[idx+2]
 		actualCrc := crc8.Checksum(wordBytes, checksumTable)
 		if actualCrc != expectedCrc {
-			return nil, errors.Errorf("failed to validate crc for %v (expected %v but got %v)", wordBytes, expectedCrc, actualCrc)
+			return nil, &CRCError{Word: word, Expected: expectedCrc, Actual: actualCrc}
 		}
 
-		word := uint16(wordBytes[0])<<8 | uint16(wordBytes[1])
 		data = append(data, word)
 	}
 	return data, nil
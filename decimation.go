@@ -0,0 +1,139 @@
+package sensironsgp30
+
+import (
+	"time"
+
+	"github.com/go-sensors/core/gas"
+	"github.com/go-sensors/core/units"
+)
+
+// WithMeasurementInterval specifies the duration between measurements
+//
+// The sensor's on-chip baseline algorithm is designed for measurements once per second; use WithOutputDecimation to
+// publish readings less frequently without affecting the algorithm. A non-positive interval selects DefaultMeasurementInterval.
+func WithMeasurementInterval(interval time.Duration) *Option {
+	return &Option{
+		apply: func(s *Sensor) {
+			if interval <= 0 {
+				interval = DefaultMeasurementInterval
+			}
+			s.measurementInterval = interval
+		},
+	}
+}
+
+// MeasurementInterval is the duration between measurements
+func (s *Sensor) MeasurementInterval() time.Duration {
+	return s.measurementInterval
+}
+
+// WithOutputDecimation specifies the number of consecutive measurements aggregated into each published reading
+func WithOutputDecimation(samples int) *Option {
+	return &Option{
+		apply: func(s *Sensor) {
+			s.outputDecimation = samples
+		},
+	}
+}
+
+// OutputDecimation is the number of consecutive measurements aggregated into each published reading
+func (s *Sensor) OutputDecimation() int {
+	return s.outputDecimation
+}
+
+// accumulator tracks the mean, minimum, and maximum of a series of concentrations
+type accumulator struct {
+	count int
+	sum   float64
+	min   units.Concentration
+	max   units.Concentration
+}
+
+func (a *accumulator) add(amount units.Concentration) {
+	if a.count == 0 || amount < a.min {
+		a.min = amount
+	}
+	if a.count == 0 || amount > a.max {
+		a.max = amount
+	}
+	a.count++
+	a.sum += float64(amount)
+}
+
+func (a *accumulator) mean() units.Concentration {
+	return units.Concentration(a.sum / float64(a.count))
+}
+
+func (a *accumulator) span() Range {
+	return Range{Min: a.min, Max: a.max}
+}
+
+// decimateReading collects readings until enough have been measured to publish their aggregate, returning nil until then
+func (s *Sensor) decimateReading(reading *Reading) *Reading {
+	if s.outputDecimation <= 1 {
+		return reading
+	}
+
+	s.pendingReadings = append(s.pendingReadings, reading)
+	if len(s.pendingReadings) < s.outputDecimation {
+		return nil
+	}
+
+	tvoc := &accumulator{}
+	co2eq := &accumulator{}
+	last := s.pendingReadings[len(s.pendingReadings)-1]
+	aggregate := &Reading{
+		Sequence:  last.Sequence,
+		Timestamp: last.Timestamp,
+		SerialID:  last.SerialID,
+		Samples:   len(s.pendingReadings),
+	}
+	for _, r := range s.pendingReadings {
+		tvoc.add(r.TVOC)
+		co2eq.add(r.CO2eq)
+		aggregate.WarmingUp = aggregate.WarmingUp || r.WarmingUp
+		aggregate.EarlyOperationPhase = aggregate.EarlyOperationPhase || r.EarlyOperationPhase
+	}
+	aggregate.TVOC = tvoc.mean()
+	aggregate.TVOCRange = tvoc.span()
+	aggregate.CO2eq = co2eq.mean()
+	aggregate.CO2eqRange = co2eq.span()
+
+	s.pendingReadings = nil
+	return aggregate
+}
+
+// decimateConcentrations collects concentrations until enough have been measured to publish their means, returning nil until then
+func (s *Sensor) decimateConcentrations(concentrations []*gas.Concentration) []*gas.Concentration {
+	if s.outputDecimation <= 1 {
+		return concentrations
+	}
+
+	if s.pendingConcentrations == nil {
+		s.pendingConcentrations = map[string]*accumulator{}
+	}
+	s.pendingConcentrationSamples++
+	for _, concentration := range concentrations {
+		a, ok := s.pendingConcentrations[concentration.Gas]
+		if !ok {
+			a = &accumulator{}
+			s.pendingConcentrations[concentration.Gas] = a
+		}
+		a.add(concentration.Amount)
+	}
+	if s.pendingConcentrationSamples < s.outputDecimation {
+		return nil
+	}
+
+	means := []*gas.Concentration{}
+	for _, concentration := range concentrations {
+		means = append(means, &gas.Concentration{
+			Gas:    concentration.Gas,
+			Amount: s.pendingConcentrations[concentration.Gas].mean(),
+		})
+	}
+
+	s.pendingConcentrations = nil
+	s.pendingConcentrationSamples = 0
+	return means
+}
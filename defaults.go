@@ -8,6 +8,12 @@ import (
 
 const (
 	DefaultReconnectTimeout = 5 * time.Second
+	DefaultBaselineInterval = 1 * time.Hour
+
+	DefaultMeasurementInterval  = 1 * time.Second
+	DefaultHumidityRateLimit    = 10 * time.Second
+	DefaultHumidityStaleTimeout = 10 * time.Minute
+	DefaultOutputBufferSize     = 64
 )
 
 // GetDefaultI2CPortConfig gets the manufacturer-specified defaults for connecting to the sensor
@@ -16,3 +22,10 @@ func GetDefaultI2CPortConfig() *i2c.I2CPortConfig {
 		Address: 0x58,
 	}
 }
+
+// GetGeneralCallI2CPortConfig gets the configuration for addressing the I2C general call used to reset the sensor
+func GetGeneralCallI2CPortConfig() *i2c.I2CPortConfig {
+	return &i2c.I2CPortConfig{
+		Address: 0x00,
+	}
+}
@@ -21,3 +21,17 @@ func Test_GetDefaultI2CPortConfig_returns_expected_configuration(t *testing.T) {
 	assert.NotNil(t, actual)
 	assert.EqualValues(t, expected, actual)
 }
+
+func Test_GetGeneralCallI2CPortConfig_returns_expected_configuration(t *testing.T) {
+	// Arrange
+	expected := &i2c.I2CPortConfig{
+		Address: 0x00,
+	}
+
+	// Act
+	actual := sensironsgp30.GetGeneralCallI2CPortConfig()
+
+	// Assert
+	assert.NotNil(t, actual)
+	assert.EqualValues(t, expected, actual)
+}
@@ -0,0 +1,146 @@
+package sensironsgp30
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// WithOutputBufferSize specifies the number of readings, concentrations and raw signals buffered between the sensor and
+// their delivery to subscribers, beyond which the oldest are discarded
+func WithOutputBufferSize(size int) *Option {
+	return &Option{
+		apply: func(s *Sensor) {
+			s.outputBufferSize = size
+		},
+	}
+}
+
+// OutputBufferSize is the number of readings, concentrations and raw signals buffered between the sensor and their delivery to subscribers
+func (s *Sensor) OutputBufferSize() int {
+	return s.outputBufferSize
+}
+
+// DeliveryStats describes how promptly the sensor's output has been delivered to subscribers
+type DeliveryStats struct {
+	// Published is the number of readings, concentrations and raw signals obtained from the sensor
+	Published uint64
+	// Delivered is the number of published outputs delivered to every subscriber
+	Delivered uint64
+	// Overwritten is the number of published outputs discarded because the output buffer was full
+	Overwritten uint64
+	// Pending is the number of published outputs waiting to be delivered
+	Pending int
+	// RawSignalsDropped is the number of raw signal readings discarded because they were not received from RawSignals in time
+	RawSignalsDropped uint64
+	// SharedDropped is the number of readings and concentrations discarded because they were not received from Readings or
+	// Concentrations in time
+	SharedDropped uint64
+	// LastLag is the time elapsed between publishing the most recently delivered output and completing its delivery
+	LastLag time.Duration
+	// MaxLag is the largest lag observed
+	MaxLag time.Duration
+}
+
+// DeliveryStats gets statistics for the delivery of the sensor's output to subscribers
+func (s *Sensor) DeliveryStats() *DeliveryStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := s.deliveryStats
+	stats.Pending = len(s.pendingOutputs)
+	stats.RawSignalsDropped = atomic.LoadUint64(&s.rawSignalsDropped)
+	stats.SharedDropped = s.legacySubscription.Dropped()
+	return &stats
+}
+
+type pendingOutput struct {
+	output      interface{}
+	publishedAt time.Time
+}
+
+// publish buffers output for asynchronous delivery so that the command loop never waits for subscribers
+func (s *Sensor) publish(output interface{}) {
+	s.mu.Lock()
+	s.deliveryStats.Published++
+	if len(s.pendingOutputs) > 0 && len(s.pendingOutputs) >= s.outputBufferSize {
+		s.pendingOutputs = s.pendingOutputs[1:]
+		s.deliveryStats.Overwritten++
+	}
+	s.pendingOutputs = append(s.pendingOutputs, &pendingOutput{
+		output:      output,
+		publishedAt: time.Now(),
+	})
+	s.mu.Unlock()
+
+	select {
+	case s.outputsReady <- struct{}{}:
+	default:
+	}
+}
+
+// nextOutput removes the oldest published output from the buffer along with the subscriptions it should be delivered to
+func (s *Sensor) nextOutput() (*pendingOutput, []*Subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.pendingOutputs) == 0 {
+		return nil, nil
+	}
+	next := s.pendingOutputs[0]
+	s.pendingOutputs[0] = nil
+	s.pendingOutputs = s.pendingOutputs[1:]
+	// The shared subscription is served last so that its consumers cannot delay independent subscribers
+	subscriptions := make([]*Subscription, 0, len(s.subscriptions)+1)
+	subscriptions = append(subscriptions, s.subscriptions...)
+	subscriptions = append(subscriptions, s.legacySubscription)
+	return next, subscriptions
+}
+
+func (s *Sensor) recordDelivery(lag time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deliveryStats.Delivered++
+	s.deliveryStats.LastLag = lag
+	if lag > s.deliveryStats.MaxLag {
+		s.deliveryStats.MaxLag = lag
+	}
+}
+
+// dispatch delivers published output until the run is done, then delivers whatever output remains before returning
+func (s *Sensor) dispatch(done <-chan struct{}) {
+	for {
+		select {
+		case <-done:
+			s.deliverPending()
+			return
+		case <-s.outputsReady:
+			s.deliverPending()
+		}
+	}
+}
+
+// deliverPending delivers published output to the raw signals channel and every subscription until none remains
+func (s *Sensor) deliverPending() {
+	// A dispatcher left delivering after its run stopped must not interleave with the dispatcher of the next run
+	s.dispatchMu.Lock()
+	defer s.dispatchMu.Unlock()
+
+	for {
+		next, subscriptions := s.nextOutput()
+		if next == nil {
+			return
+		}
+
+		switch output := next.output.(type) {
+		case *RawSignals:
+			offer(context.Background(), OverflowDropOldest, &s.rawSignalsDropped, s.rawSignals, output)
+		default:
+			for _, subscription := range subscriptions {
+				subscription.deliver(output)
+			}
+		}
+		s.recordDelivery(time.Since(next.publishedAt))
+	}
+}
@@ -0,0 +1,55 @@
+package sensironsgp30
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrSelfTestFailed indicates that the sensor's on-chip self test did not return the expected pattern
+	ErrSelfTestFailed = errors.New("self test failed")
+	// ErrUnsupported indicates that the sensor's feature set or configuration does not support the requested command
+	ErrUnsupported = errors.New("unsupported by sensor")
+	// ErrInvalidHumidity indicates that a humidity cannot be used to compensate the sensor's measurements
+	ErrInvalidHumidity = errors.New("invalid humidity")
+	// ErrNotRunning indicates that a command was issued to a Sensor before Run was called
+	ErrNotRunning = errors.New("sensor is not running")
+	// ErrStopped indicates that a command was issued to a Sensor after Run returned
+	ErrStopped = errors.New("sensor has stopped")
+	// ErrAlreadyRunning indicates that Run was called while the Sensor was already running
+	ErrAlreadyRunning = errors.New("sensor is already running")
+	// ErrShortRead indicates that the sensor returned fewer bytes than the command's response requires
+	ErrShortRead = errors.New("short read")
+)
+
+// BusError indicates that communicating with the sensor over the port failed while issuing a command
+type BusError struct {
+	// Command is the code of the command being issued
+	Command uint16
+	// Err is the error reported by the port
+	Err error
+}
+
+func (e *BusError) Error() string {
+	return fmt.Sprintf("failed to communicate with sensor for command %#04x: %v", e.Command, e.Err)
+}
+
+// Unwrap gets the error reported by the port
+func (e *BusError) Unwrap() error {
+	return e.Err
+}
+
+// CRCError indicates that a word read from the sensor did not match the checksum sent alongside it
+type CRCError struct {
+	// Word is the word read from the sensor
+	Word uint16
+	// Expected is the checksum sent by the sensor
+	Expected uint8
+	// Actual is the checksum calculated for the word
+	Actual uint8
+}
+
+func (e *CRCError) Error() string {
+	return fmt.Sprintf("failed to validate crc for word %#04x (expected %#02x but got %#02x)", e.Word, e.Expected, e.Actual)
+}
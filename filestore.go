@@ -0,0 +1,109 @@
+package sensironsgp30
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// MaxBaselineAge is the vendor-specified maximum age of a baseline that may be restored to the sensor
+	MaxBaselineAge time.Duration = 7 * 24 * time.Hour
+)
+
+// FileBaselineStore is a BaselineStore that persists the baseline as a JSON document on disk
+type FileBaselineStore struct {
+	path string
+}
+
+// NewFileBaselineStore creates a FileBaselineStore that reads and writes the file at the given path
+func NewFileBaselineStore(path string) *FileBaselineStore {
+	return &FileBaselineStore{
+		path: path,
+	}
+}
+
+// Path is the location of the file used to persist the baseline
+func (f *FileBaselineStore) Path() string {
+	return f.path
+}
+
+type baselineRecord struct {
+	CO2eq     uint16    `json:"co2eq"`
+	TVOC      uint16    `json:"tvoc"`
+	SerialID  uint64    `json:"serialId"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Load gets the saved baseline, ignoring baselines that are unreadable, belong to a different sensor or are older than MaxBaselineAge
+func (f *FileBaselineStore) Load(ctx context.Context, serialID uint64) (*Baseline, error) {
+	data, err := os.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read baseline file")
+	}
+
+	record := &baselineRecord{}
+	err = json.Unmarshal(data, record)
+	if err != nil {
+		// A corrupt or partially written file is replaced by the next save, so it must not prevent the sensor from starting
+		return nil, nil
+	}
+
+	if record.SerialID != serialID {
+		return nil, nil
+	}
+	if time.Since(record.Timestamp) > MaxBaselineAge {
+		return nil, nil
+	}
+
+	baseline := &Baseline{
+		CO2eq: record.CO2eq,
+		TVOC:  record.TVOC,
+	}
+	return baseline, nil
+}
+
+// Save atomically replaces the saved baseline with the given baseline
+func (f *FileBaselineStore) Save(ctx context.Context, serialID uint64, baseline *Baseline) error {
+	record := &baselineRecord{
+		CO2eq:     baseline.CO2eq,
+		TVOC:      baseline.TVOC,
+		SerialID:  serialID,
+		Timestamp: time.Now().UTC(),
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize baseline")
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary baseline file")
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	closeErr := file.Close()
+	if err != nil {
+		return errors.Wrap(err, "failed to write temporary baseline file")
+	}
+	if closeErr != nil {
+		return errors.Wrap(closeErr, "failed to close temporary baseline file")
+	}
+
+	err = os.Rename(file.Name(), f.path)
+	if err != nil {
+		return errors.Wrap(err, "failed to replace baseline file")
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package sensironsgp30_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-sensors/sensironsgp30"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FileBaselineStore_returns_nothing_when_file_is_missing(t *testing.T) {
+	// Arrange
+	store := sensironsgp30.NewFileBaselineStore(filepath.Join(t.TempDir(), "baseline.json"))
+
+	// Act
+	actual, err := store.Load(context.Background(), 0x0000_0123_4567)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Nil(t, actual)
+}
+
+func Test_FileBaselineStore_loads_saved_baseline(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	store := sensironsgp30.NewFileBaselineStore(filepath.Join(dir, "baseline.json"))
+	expected := &sensironsgp30.Baseline{
+		CO2eq: 0x8F3A,
+		TVOC:  0x9122,
+	}
+
+	// Act
+	err := store.Save(context.Background(), 0x0000_0123_4567, expected)
+	assert.Nil(t, err)
+	actual, err := store.Load(context.Background(), 0x0000_0123_4567)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+	entries, err := os.ReadDir(dir)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func Test_FileBaselineStore_ignores_baseline_from_another_sensor(t *testing.T) {
+	// Arrange
+	store := sensironsgp30.NewFileBaselineStore(filepath.Join(t.TempDir(), "baseline.json"))
+	err := store.Save(context.Background(), 0x0000_0123_4567, &sensironsgp30.Baseline{CO2eq: 0x8F3A, TVOC: 0x9122})
+	assert.Nil(t, err)
+
+	// Act
+	actual, err := store.Load(context.Background(), 0x0000_89AB_CDEF)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Nil(t, actual)
+}
+
+func Test_FileBaselineStore_ignores_expired_baseline(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	store := sensironsgp30.NewFileBaselineStore(path)
+	timestamp := time.Now().Add(-sensironsgp30.MaxBaselineAge - time.Minute).UTC().Format(time.RFC3339)
+	err := os.WriteFile(path, []byte(`{"co2eq":36666,"tvoc":37154,"serialId":19088743,"timestamp":"`+timestamp+`"}`), 0644)
+	assert.Nil(t, err)
+
+	// Act
+	actual, err := store.Load(context.Background(), 0x0000_0123_4567)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Nil(t, actual)
+}
+
+func Test_FileBaselineStore_ignores_corrupt_file(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	store := sensironsgp30.NewFileBaselineStore(path)
+	err := os.WriteFile(path, []byte("not a baseline"), 0644)
+	assert.Nil(t, err)
+
+	// Act
+	actual, err := store.Load(context.Background(), 0x0000_0123_4567)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Nil(t, actual)
+}
+
+func Test_FileBaselineStore_ignores_truncated_file(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	store := sensironsgp30.NewFileBaselineStore(path)
+	err := os.WriteFile(path, []byte(`{"co2eq":36666,"tvoc":37154,"serialId":190`), 0644)
+	assert.Nil(t, err)
+
+	// Act
+	actual, err := store.Load(context.Background(), 0x0000_0123_4567)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Nil(t, actual)
+}
@@ -0,0 +1,227 @@
+package sensironsgp30
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-sensors/core/units"
+)
+
+// WithHumiditySource specifies a channel of relative humidity readings, such as those from a companion sensor, used to compensate the sensor's measurements
+func WithHumiditySource(source <-chan *units.RelativeHumidity) *Option {
+	return &Option{
+		apply: func(s *Sensor) {
+			s.humiditySource = source
+		},
+	}
+}
+
+// HumiditySource is the channel of relative humidity readings used to compensate the sensor's measurements, or nil if there is none
+func (s *Sensor) HumiditySource() <-chan *units.RelativeHumidity {
+	return s.humiditySource
+}
+
+// WithHumidityRateLimit specifies the minimum duration between humidity updates forwarded from the humidity source to the sensor
+func WithHumidityRateLimit(limit time.Duration) *Option {
+	return &Option{
+		apply: func(s *Sensor) {
+			s.humidityRateLimit = limit
+		},
+	}
+}
+
+// HumidityRateLimit is the minimum duration between humidity updates forwarded from the humidity source to the sensor
+func (s *Sensor) HumidityRateLimit() time.Duration {
+	return s.humidityRateLimit
+}
+
+// WithHumidityStaleTimeout specifies the duration without readings from the humidity source after which humidity
+// compensation is disabled; a non-positive timeout selects DefaultHumidityStaleTimeout
+func WithHumidityStaleTimeout(timeout time.Duration) *Option {
+	return &Option{
+		apply: func(s *Sensor) {
+			if timeout <= 0 {
+				timeout = DefaultHumidityStaleTimeout
+			}
+			s.humidityStaleTimeout = timeout
+		},
+	}
+}
+
+// HumidityStaleTimeout is the duration without readings from the humidity source after which humidity compensation is disabled
+func (s *Sensor) HumidityStaleTimeout() time.Duration {
+	return s.humidityStaleTimeout
+}
+
+// HandleRelativeHumidity compensates the sensor's measurements for the absolute humidity corresponding to the given relative humidity
+func (s *Sensor) HandleRelativeHumidity(ctx context.Context, relativeHumidity *units.RelativeHumidity) error {
+	return s.HandleAbsoluteHumidity(ctx, relativeHumidity.AbsoluteHumidity())
+}
+
+// HandleAbsoluteHumidity compensates the sensor's measurements for the given absolute humidity, which must be greater than zero and less than 256 g/m³
+//
+// The most recently handled humidity is remembered and applied whenever the sensor has been initialized, including after reconnecting.
+func (s *Sensor) HandleAbsoluteHumidity(ctx context.Context, absoluteHumidity units.MassConcentration) error {
+	fixedPointValue, err := encodeAbsoluteHumidity(absoluteHumidity)
+	if err != nil {
+		return err
+	}
+	return s.compensateHumidity(ctx, &setAbsoluteHumidity{fixedPointValue: fixedPointValue})
+}
+
+// DisableHumidityCompensation stops compensating the sensor's measurements for humidity until a humidity is handled again
+func (s *Sensor) DisableHumidityCompensation(ctx context.Context) error {
+	return s.compensateHumidity(ctx, &disableHumidityCompensation{})
+}
+
+// HumidityCompensation describes the humidity most recently handled to compensate the sensor's measurements
+type HumidityCompensation struct {
+	// AbsoluteHumidity is the absolute humidity as encoded for the sensor, or zero if compensation is disabled
+	AbsoluteHumidity units.MassConcentration
+	// HandledAt is when the humidity was handled
+	HandledAt time.Time
+
+	fixedPointValue uint16
+	fromSource      bool
+}
+
+// Age is the duration since the humidity was handled
+func (h *HumidityCompensation) Age() time.Duration {
+	return time.Since(h.HandledAt)
+}
+
+// HumidityCompensation gets the humidity most recently handled, which is replayed whenever the sensor is reconnected, or nil if none has been handled
+func (s *Sensor) HumidityCompensation() *HumidityCompensation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.humidity == nil {
+		return nil
+	}
+	humidity := *s.humidity
+	return &humidity
+}
+
+// rememberHumidity records the humidity compensation command most recently handled and whether it was forwarded from
+// the humidity source; the caller must hold the lock
+func (s *Sensor) rememberHumidity(command interface{}, fromSource bool) {
+	var fixedPointValue uint16
+	if command, ok := command.(*setAbsoluteHumidity); ok {
+		fixedPointValue = command.fixedPointValue
+	}
+
+	s.humidity = &HumidityCompensation{
+		AbsoluteHumidity: units.MassConcentration(float64(fixedPointValue)/256) * units.GramPerCubicMeter,
+		HandledAt:        time.Now(),
+		fixedPointValue:  fixedPointValue,
+		fromSource:       fromSource,
+	}
+}
+
+type setAbsoluteHumidity struct {
+	fixedPointValue uint16
+}
+
+type disableHumidityCompensation struct{}
+
+// forwardHumidity sends the latest reading from the humidity source to the sensor no more often than the rate limit allows,
+// and disables humidity compensation when the source has not produced a reading within the stale timeout
+func (s *Sensor) forwardHumidity(ctx context.Context) func() error {
+	return func() error {
+		source := s.humiditySource
+		var pending *setAbsoluteHumidity
+		var lastSent time.Time
+		var limited <-chan time.Time
+
+		// stale is only received from while the timer is armed, so that a stopped timer is never waited on
+		var stale <-chan time.Time
+		staleTimer := time.NewTimer(s.humidityStaleTimeout)
+		staleTimer.Stop()
+		defer staleTimer.Stop()
+		armStale := func(timeout time.Duration) {
+			if !staleTimer.Stop() {
+				select {
+				case <-staleTimer.C:
+				default:
+				}
+			}
+			staleTimer.Reset(timeout)
+			stale = staleTimer.C
+		}
+
+		// A humidity from the source replayed after reconnecting becomes stale as if the connection had never been
+		// interrupted, while a humidity handled explicitly is kept until it is replaced
+		humidity := s.HumidityCompensation()
+		if humidity != nil && humidity.fromSource && humidity.fixedPointValue != 0 {
+			armStale(s.humidityStaleTimeout - humidity.Age())
+		}
+
+		send := func(command interface{}) bool {
+			s.mu.Lock()
+			s.rememberHumidity(command, true)
+			s.mu.Unlock()
+
+			select {
+			case <-ctx.Done():
+				return false
+			case s.commands <- command:
+				return true
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case relativeHumidity, ok := <-source:
+				if !ok {
+					source = nil
+					continue
+				}
+				if relativeHumidity == nil {
+					continue
+				}
+				fixedPointValue, err := encodeAbsoluteHumidity(relativeHumidity.AbsoluteHumidity())
+				if err != nil {
+					continue
+				}
+
+				pending = &setAbsoluteHumidity{fixedPointValue: fixedPointValue}
+				armStale(s.humidityStaleTimeout)
+				if limited != nil {
+					continue
+				}
+
+				wait := s.humidityRateLimit - time.Since(lastSent)
+				if wait > 0 {
+					limited = time.After(wait)
+					continue
+				}
+
+				if !send(pending) {
+					return nil
+				}
+				pending = nil
+				lastSent = time.Now()
+			case <-limited:
+				limited = nil
+				if pending == nil {
+					continue
+				}
+
+				if !send(pending) {
+					return nil
+				}
+				pending = nil
+				lastSent = time.Now()
+			case <-stale:
+				stale = nil
+				limited = nil
+				pending = nil
+				if !send(&disableHumidityCompensation{}) {
+					return nil
+				}
+			}
+		}
+	}
+}
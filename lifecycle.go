@@ -0,0 +1,112 @@
+package sensironsgp30
+
+import (
+	"context"
+)
+
+// start marks the Sensor as running, unless it is already running, and returns the channel closed when the run stops
+func (s *Sensor) start() (<-chan struct{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return nil, ErrAlreadyRunning
+	}
+	s.running = true
+	s.stopped = false
+	s.done = make(chan struct{})
+	return s.done, nil
+}
+
+// stop marks the Sensor as stopped and releases any callers waiting on the command loop
+func (s *Sensor) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.running = false
+	s.connected = false
+	s.stopped = true
+	close(s.done)
+}
+
+// checkRunning reports whether commands can currently be sent to the command loop and returns the channel closed when
+// the current run stops; the caller must hold the lock
+func (s *Sensor) checkRunning() (<-chan struct{}, error) {
+	if s.stopped {
+		return nil, ErrStopped
+	}
+	if !s.running {
+		return nil, ErrNotRunning
+	}
+	return s.done, nil
+}
+
+// connect marks the sensor as ready to handle commands and returns the humidity compensation to replay, if any
+func (s *Sensor) connect() *HumidityCompensation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.connected = true
+	return s.humidity
+}
+
+// disconnect marks the sensor as unable to handle commands until it is connected again
+func (s *Sensor) disconnect() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.connected = false
+}
+
+// compensateHumidity remembers a humidity compensation command and sends it to the command loop when the sensor is connected
+func (s *Sensor) compensateHumidity(ctx context.Context, command interface{}) error {
+	s.mu.Lock()
+	done, err := s.checkRunning()
+	connected := s.connected
+	if err == nil {
+		s.rememberHumidity(command, false)
+	}
+	s.mu.Unlock()
+	if err != nil || !connected {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-done:
+		return ErrStopped
+	case s.commands <- command:
+	}
+	return nil
+}
+
+func (s *Sensor) execute(ctx context.Context, command interface{}, r request) error {
+	s.mu.Lock()
+	done, err := s.checkRunning()
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-done:
+		return ErrStopped
+	case s.commands <- command:
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-r.done:
+		return err
+	case <-done:
+		select {
+		case err := <-r.done:
+			return err
+		default:
+			return ErrStopped
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package sensironsgp30
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-sensors/core/gas"
+	"github.com/go-sensors/core/units"
+)
+
+// Range represents the minimum and maximum of a concentration over the measurements aggregated into a reading
+type Range struct {
+	Min units.Concentration
+	Max units.Concentration
+}
+
+// Reading represents the air quality signals obtained from one or more consecutive measurements by the sensor
+type Reading struct {
+	// Sequence is the position of the most recent measurement in the reading among all measurements taken by the Sensor
+	Sequence uint64
+	// Timestamp is when the most recent measurement in the reading was obtained from the sensor
+	Timestamp time.Time
+	// SerialID is the serial ID of the sensor that measured the reading
+	SerialID uint64
+	// Samples is the number of measurements aggregated into the reading
+	Samples int
+	// TVOC is the mean total volatile organic compounds concentration
+	TVOC units.Concentration
+	// TVOCRange is the range of total volatile organic compounds concentrations
+	TVOCRange Range
+	// CO2eq is the mean carbon dioxide equivalent concentration
+	CO2eq units.Concentration
+	// CO2eqRange is the range of carbon dioxide equivalent concentrations
+	CO2eqRange Range
+	// WarmingUp indicates whether any measurement in the reading was taken while the sensor was warming up and reported fixed values
+	WarmingUp bool
+	// EarlyOperationPhase indicates whether any measurement in the reading was taken during the early operation phase and is less accurate
+	EarlyOperationPhase bool
+}
+
+// Concentrations gets the gas concentrations contained in the reading
+func (r *Reading) Concentrations() []*gas.Concentration {
+	return []*gas.Concentration{
+		{
+			Gas:    TotalVolatileOrganicCompounds,
+			Amount: r.TVOC,
+		},
+		{
+			Gas:    CarbonDioxideEquivalent,
+			Amount: r.CO2eq,
+		},
+	}
+}
+
+// Readings returns a channel of readings as they become available from the sensor
+//
+// Readings and Concentrations are views of a single shared subscription that buffers up to 16 readings and 16
+// concentrations, discarding the oldest when a consumer falls behind; use Subscribe to choose the buffering and overflow policy.
+// Output published before either is first called is buffered in the same way, so the first measurements are not lost.
+// The number discarded is reported by DeliveryStats as SharedDropped.
+func (s *Sensor) Readings() <-chan *Reading {
+	return s.legacySubscription.Readings()
+}
+
+// Concentrations returns a channel of concentration readings as they become available from the sensor
+//
+// Readings and Concentrations are views of a single shared subscription that buffers up to 16 readings and 16
+// concentrations, discarding the oldest when a consumer falls behind; use Subscribe to choose the buffering and overflow policy.
+// Output published before either is first called is buffered in the same way, so the first measurements are not lost.
+// The number discarded is reported by DeliveryStats as SharedDropped.
+func (s *Sensor) Concentrations() <-chan *gas.Concentration {
+	return s.legacySubscription.Concentrations()
+}
+
+// Latest gets the most recent reading measured by the sensor, without aggregation, and whether one has been measured
+func (s *Sensor) Latest() (*Reading, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.latest == nil {
+		return nil, false
+	}
+	reading := *s.latest
+	return &reading, true
+}
+
+// Read waits for the sensor's next measurement and gets the resulting reading, without aggregation
+func (s *Sensor) Read(ctx context.Context) (*Reading, error) {
+	s.mu.Lock()
+	done, err := s.checkRunning()
+	updated := s.latestUpdated
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-done:
+		return nil, ErrStopped
+	case <-updated:
+	}
+
+	reading, _ := s.Latest()
+	return reading, nil
+}
+
+// setLatest caches the given reading and wakes any callers waiting to read it
+func (s *Sensor) setLatest(reading *Reading) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latest = reading
+	close(s.latestUpdated)
+	s.latestUpdated = make(chan struct{})
+}
+
+func (s *Sensor) newReading(airQuality *airQuality) *Reading {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sequence++
+	status := s.status()
+	return &Reading{
+		Sequence:            s.sequence,
+		Timestamp:           time.Now(),
+		SerialID:            s.serialID,
+		Samples:             1,
+		TVOC:                airQuality.TVOC,
+		TVOCRange:           Range{Min: airQuality.TVOC, Max: airQuality.TVOC},
+		CO2eq:               airQuality.CO2eq,
+		CO2eqRange:          Range{Min: airQuality.CO2eq, Max: airQuality.CO2eq},
+		WarmingUp:           status.WarmingUp,
+		EarlyOperationPhase: status.EarlyOperationPhase,
+	}
+}
@@ -0,0 +1,92 @@
+package sensironsgp30
+
+import (
+	"context"
+	"time"
+)
+
+// MeasurementStats describes how closely air quality measurements have kept to the measurement interval
+type MeasurementStats struct {
+	// Measurements is the number of air quality measurements taken
+	Measurements uint64
+	// LastPeriod is the time elapsed between the two most recent measurements
+	LastPeriod time.Duration
+	// LastJitter is the absolute difference between the last period and the measurement interval
+	LastJitter time.Duration
+	// MaxJitter is the largest jitter observed
+	MaxJitter time.Duration
+	// Overruns is the number of scheduled measurements that were skipped because the previous one had not yet been handled
+	Overruns uint64
+}
+
+// MeasurementStats gets timing statistics for the air quality measurements taken by the sensor
+func (s *Sensor) MeasurementStats() *MeasurementStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := s.measurementStats
+	return &stats
+}
+
+func (s *Sensor) recordMeasurement(interval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.measurementStats.Measurements++
+	if !s.lastMeasurementAt.IsZero() {
+		period := now.Sub(s.lastMeasurementAt)
+		jitter := period - interval
+		if jitter < 0 {
+			jitter = -jitter
+		}
+
+		s.measurementStats.LastPeriod = period
+		s.measurementStats.LastJitter = jitter
+		if jitter > s.measurementStats.MaxJitter {
+			s.measurementStats.MaxJitter = jitter
+		}
+	}
+	s.lastMeasurementAt = now
+}
+
+func (s *Sensor) recordOverrun() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.measurementStats.Overruns++
+}
+
+// requestMeasurementsRepeatedly requests measurements on ticks anchored to when it started, so that command latency
+// does not accumulate into the period; a tick that elapses before the previous request is accepted is skipped
+func (s *Sensor) requestMeasurementsRepeatedly(
+	ctx context.Context,
+	interval time.Duration,
+	requests ...interface{}) func() error {
+	return func() error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+
+			for _, request := range requests {
+				sent := false
+				for !sent {
+					select {
+					case <-ctx.Done():
+						return nil
+					case s.commands <- request:
+						sent = true
+					case <-ticker.C:
+						s.recordOverrun()
+					}
+				}
+			}
+		}
+	}
+}
@@ -2,6 +2,8 @@ package sensironsgp30
 
 import (
 	"context"
+	"math"
+	"sync"
 	"time"
 
 	"github.com/go-sensors/core/gas"
@@ -14,15 +16,61 @@ import (
 const (
 	TotalVolatileOrganicCompounds string = "TVOC"
 	CarbonDioxideEquivalent       string = "CO2eq"
+	Hydrogen                      string = "H2"
+	Ethanol                       string = "Ethanol"
 )
 
 // Sensor represents a configured Sensiron SGP30 gas sensor
 type Sensor struct {
-	gases            chan *gas.Concentration
-	portFactory      coreio.PortFactory
-	reconnectTimeout time.Duration
-	errorHandlerFunc ShouldTerminate
-	commands         chan interface{}
+	portFactory            coreio.PortFactory
+	reconnectTimeout       time.Duration
+	errorHandlerFunc       ShouldTerminate
+	commands               chan interface{}
+	baselineStore          BaselineStore
+	baselineHandlerFunc    BaselineHandler
+	baselineInterval       time.Duration
+	warmUpPolicy           WarmUpPolicy
+	rawSignals             chan *RawSignals
+	rawSignalsEnabled      bool
+	hydrogenReference      *SignalReference
+	ethanolReference       *SignalReference
+	generalCallPortFactory coreio.PortFactory
+
+	mu                 sync.Mutex
+	featureSet         *FeatureSet
+	serialID           uint64
+	sequence           uint64
+	legacySubscription *Subscription
+	subscriptions      []*Subscription
+	dispatchMu         sync.Mutex
+	outputBufferSize   int
+	pendingOutputs     []*pendingOutput
+	outputsReady       chan struct{}
+	deliveryStats      DeliveryStats
+	rawSignalsDropped  uint64
+	latest             *Reading
+	latestUpdated      chan struct{}
+	measurementStats   MeasurementStats
+	lastMeasurementAt  time.Time
+
+	measurementInterval         time.Duration
+	outputDecimation            int
+	pendingReadings             []*Reading
+	pendingConcentrations       map[string]*accumulator
+	pendingConcentrationSamples int
+
+	humiditySource       <-chan *units.RelativeHumidity
+	humidityRateLimit    time.Duration
+	humidityStaleTimeout time.Duration
+	initializedAt        time.Time
+	baselineRestored     bool
+
+	done         chan struct{}
+	running      bool
+	stopped      bool
+	connected    bool
+	humidity     *HumidityCompensation
+	liveBaseline *liveBaseline
 }
 
 // Option is a configured option that may be applied to a Sensor
@@ -32,14 +80,23 @@ type Option struct {
 
 // NewSensor creates a Sensor with optional configuration
 func NewSensor(portFactory coreio.PortFactory, options ...*Option) *Sensor {
-	gases := make(chan *gas.Concentration)
 	commands := make(chan interface{})
+	rawSignals := make(chan *RawSignals, legacyBufferSize)
 	s := &Sensor{
-		gases:            gases,
-		portFactory:      portFactory,
-		reconnectTimeout: DefaultReconnectTimeout,
-		errorHandlerFunc: nil,
-		commands:         commands,
+		legacySubscription:   newLegacySubscription(),
+		outputBufferSize:     DefaultOutputBufferSize,
+		outputsReady:         make(chan struct{}, 1),
+		latestUpdated:        make(chan struct{}),
+		rawSignals:           rawSignals,
+		portFactory:          portFactory,
+		reconnectTimeout:     DefaultReconnectTimeout,
+		errorHandlerFunc:     nil,
+		commands:             commands,
+		baselineInterval:     DefaultBaselineInterval,
+		measurementInterval:  DefaultMeasurementInterval,
+		outputDecimation:     1,
+		humidityRateLimit:    DefaultHumidityRateLimit,
+		humidityStaleTimeout: DefaultHumidityStaleTimeout,
 	}
 	for _, o := range options {
 		o.apply(s)
@@ -79,15 +136,40 @@ func (s *Sensor) RecoverableErrorHandler() ShouldTerminate {
 }
 
 const (
-	setValueTimeout           time.Duration = 10 * time.Millisecond
-	readValueTimeout          time.Duration = 12 * time.Millisecond
-	measureAirQualityInterval time.Duration = 1 * time.Second
+	sgp30ProductType           uint8 = 0
+	tvocBaselineProductVersion uint8 = 0x21
+)
+
+const (
+	setValueTimeout       time.Duration = 10 * time.Millisecond
+	readValueTimeout      time.Duration = 12 * time.Millisecond
+	readRawSignalsTimeout time.Duration = 25 * time.Millisecond
+	measureTestTimeout    time.Duration = 220 * time.Millisecond
+	resetTimeout          time.Duration = 1 * time.Millisecond
+	readSerialIDTimeout   time.Duration = 1 * time.Millisecond
+	readFeatureSetTimeout time.Duration = 10 * time.Millisecond
 )
 
 // Run begins reading from the sensor and blocks until either an error occurs or the context is completed
+//
+// Errors that occur while the sensor is connected are recoverable: they are passed to the RecoverableErrorHandler, if one
+// is registered, and unless the handler asks to terminate, the port is reopened after the reconnect timeout. These include
+// *BusError, *CRCError and ErrShortRead from communicating with the sensor, ErrUnsupported when an unexpected product type
+// is detected, and errors from loading a baseline from the BaselineStore. Errors from saving a baseline to the
+// BaselineStore are also passed to the handler, but the port is not reopened for them. Failing to open the port is not
+// recoverable and is returned immediately.
+//
+// Run may be called again after it returns to resume reading, and the channels returned by the Sensor remain open in between.
+// Calling Run while it is already running fails with ErrAlreadyRunning. Commands issued before Run is first called fail
+// with ErrNotRunning, and those issued while it is not running after it has returned fail with ErrStopped.
 func (s *Sensor) Run(ctx context.Context) error {
-	defer close(s.gases)
-	defer close(s.commands)
+	done, err := s.start()
+	if err != nil {
+		return err
+	}
+	defer s.stop()
+
+	go s.dispatch(done)
 	for {
 		port, err := s.portFactory.Open()
 		if err != nil {
@@ -100,23 +182,48 @@ func (s *Sensor) Run(ctx context.Context) error {
 			return port.Close()
 		})
 		group.Go(func() error {
-			err = initAirQuality(innerCtx, port)
+			serialID, err := s.initialize(innerCtx, port)
 			if err != nil {
-				return errors.Wrap(err, "failed to initialize sensor")
+				return err
 			}
 
-			group.Go(handleCommands(innerCtx, s.commands, s.gases, port))
-			group.Go(requestAirQualityRepeatedly(innerCtx, s.commands))
+			group.Go(s.handleCommands(innerCtx, port))
+			requests := []interface{}{&requestAirQuality{}}
+			if s.measuresRawSignals() {
+				requests = append(requests, &requestRawSignals{})
+			}
+			group.Go(s.requestMeasurementsRepeatedly(innerCtx, s.measurementInterval, requests...))
+			if s.humiditySource != nil {
+				group.Go(s.forwardHumidity(innerCtx))
+			}
+			if s.baselineStore != nil || s.baselineHandlerFunc != nil {
+				group.Go(requestBaselineRepeatedly(innerCtx, s.commands, s.baselineInterval, s.handleBaseline(innerCtx, serialID)))
+			}
 			return nil
 		})
 
 		err = group.Wait()
+		s.disconnect()
+		var terminated *terminatedError
+		if errors.As(err, &terminated) {
+			return terminated.error
+		}
 		if s.errorHandlerFunc != nil {
 			if s.errorHandlerFunc(err) {
 				return err
 			}
 		}
 
+		if s.generalCallPortFactory != nil && ctx.Err() == nil {
+			resetErr := generalCallReset(ctx, s.generalCallPortFactory)
+			if resetErr != nil {
+				resetErr = errors.Wrap(resetErr, "failed to reset sensor")
+				if s.errorHandlerFunc != nil && s.errorHandlerFunc(resetErr) {
+					return resetErr
+				}
+			}
+		}
+
 		select {
 		case <-ctx.Done():
 			return nil
@@ -125,14 +232,148 @@ func (s *Sensor) Run(ctx context.Context) error {
 	}
 }
 
-// Concentrations returns a channel of concentration readings as they become available from the sensor
-func (s *Sensor) Concentrations() <-chan *gas.Concentration {
-	return s.gases
+// initialize brings up the sensor on the given port and returns its serial ID
+func (s *Sensor) initialize(ctx context.Context, port coreio.Port) (uint64, error) {
+	featureSet, err := getFeatureSet(ctx, port)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to detect feature set")
+	}
+	if featureSet == nil {
+		return 0, nil
+	}
+	if featureSet.ProductType != sgp30ProductType {
+		return 0, errors.Wrapf(ErrUnsupported, "unexpected product type %d", featureSet.ProductType)
+	}
+	s.setFeatureSet(featureSet)
+
+	serialID, err := getSerialID(ctx, port)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to identify sensor")
+	}
+	s.setSerialID(serialID)
+
+	err = initAirQuality(ctx, port)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to initialize sensor")
+	}
+	s.markInitialized()
+
+	// The chip forgets its humidity compensation when the baseline algorithm is initialized
+	humidity := s.connect()
+	if humidity != nil {
+		err = setHumidity(ctx, port, humidity.fixedPointValue)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to replay humidity")
+		}
+	}
+
+	replayed, err := s.replayBaseline(ctx, port, serialID)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to replay baseline")
+	}
+	if !replayed && s.baselineStore != nil {
+		restored, err := restoreBaseline(ctx, port, s.baselineStore, serialID)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to restore baseline")
+		}
+		if restored {
+			s.markBaselineRestored()
+		}
+	}
+
+	return serialID, nil
+}
+
+// WithGeneralCallPortFactory specifies a factory for a port addressed to the I2C general call address, used to reset the sensor
+//
+// Note that a general call reset affects every device on the bus that supports it.
+func WithGeneralCallPortFactory(portFactory coreio.PortFactory) *Option {
+	return &Option{
+		apply: func(s *Sensor) {
+			s.generalCallPortFactory = portFactory
+		},
+	}
+}
+
+// GeneralCallPortFactory is the factory for a port addressed to the I2C general call address, or nil if the sensor cannot be reset
+func (s *Sensor) GeneralCallPortFactory() coreio.PortFactory {
+	return s.generalCallPortFactory
+}
+
+// Reset issues an I2C general call reset and then reinitializes the sensor, replaying the most recent humidity and baseline
+func (s *Sensor) Reset(ctx context.Context) error {
+	command := &resetRequest{request: newRequest()}
+	return s.execute(ctx, command, command.request)
+}
+
+// WithRawSignals specifies whether raw H2 and ethanol signals are measured alongside the air quality signals
+func WithRawSignals(enabled bool) *Option {
+	return &Option{
+		apply: func(s *Sensor) {
+			s.rawSignalsEnabled = enabled
+		},
+	}
+}
+
+// RawSignalsEnabled indicates whether raw H2 and ethanol signals are measured alongside the air quality signals
+func (s *Sensor) RawSignalsEnabled() bool {
+	return s.rawSignalsEnabled
+}
+
+// RawSignals returns a channel of raw signal readings as they become available from the sensor, when enabled
+//
+// A limited number of raw signal readings are buffered, discarding the oldest when they are not received in time.
+func (s *Sensor) RawSignals() <-chan *RawSignals {
+	return s.rawSignals
+}
+
+// SignalReference relates a raw signal to the known concentration of a gas at which it was measured
+type SignalReference struct {
+	Signal        uint16
+	Concentration units.Concentration
+}
+
+// WithHydrogenReference specifies the reference used to convert raw H2 signals to concentrations published alongside the air quality readings
+func WithHydrogenReference(reference *SignalReference) *Option {
+	return &Option{
+		apply: func(s *Sensor) {
+			s.hydrogenReference = reference
+		},
+	}
+}
+
+// HydrogenReference is the reference used to convert raw H2 signals to concentrations, or nil if H2 concentrations are not published
+func (s *Sensor) HydrogenReference() *SignalReference {
+	return s.hydrogenReference
+}
+
+// WithEthanolReference specifies the reference used to convert raw ethanol signals to concentrations published alongside the air quality readings
+func WithEthanolReference(reference *SignalReference) *Option {
+	return &Option{
+		apply: func(s *Sensor) {
+			s.ethanolReference = reference
+		},
+	}
+}
+
+// EthanolReference is the reference used to convert raw ethanol signals to concentrations, or nil if ethanol concentrations are not published
+func (s *Sensor) EthanolReference() *SignalReference {
+	return s.ethanolReference
+}
+
+func (s *Sensor) measuresRawSignals() bool {
+	return s.rawSignalsEnabled || s.hydrogenReference != nil || s.ethanolReference != nil
+}
+
+// convert applies the vendor-specified relationship c = c_ref * exp((s_ref - s_out) / 512) to a raw signal
+func (r *SignalReference) convert(signal uint16) units.Concentration {
+	ratio := math.Exp((float64(r.Signal) - float64(signal)) / 512)
+	return units.Concentration(float64(r.Concentration) * ratio)
 }
 
 // ConcentrationSpecs returns a collection of specified measurement ranges supported by the sensor
-func (*Sensor) ConcentrationSpecs() []*gas.ConcentrationSpec {
-	return []*gas.ConcentrationSpec{
+func (s *Sensor) ConcentrationSpecs() []*gas.ConcentrationSpec {
+	specs := []*gas.ConcentrationSpec{
 		{
 			Gas:              TotalVolatileOrganicCompounds,
 			Resolution:       1 * units.PartPerBillion,
@@ -176,82 +417,363 @@ func (*Sensor) ConcentrationSpecs() []*gas.ConcentrationSpec {
 			MaxConcentration: 60000 * units.PartPerMillion,
 		},
 	}
+
+	if s.hydrogenReference != nil {
+		specs = append(specs, &gas.ConcentrationSpec{
+			Gas:              Hydrogen,
+			Resolution:       1 * units.PartPerBillion,
+			MinConcentration: 300 * units.PartPerBillion,
+			MaxConcentration: 1000 * units.PartPerMillion,
+		})
+	}
+	if s.ethanolReference != nil {
+		specs = append(specs, &gas.ConcentrationSpec{
+			Gas:              Ethanol,
+			Resolution:       1 * units.PartPerBillion,
+			MinConcentration: 300 * units.PartPerBillion,
+			MaxConcentration: 1000 * units.PartPerMillion,
+		})
+	}
+	return specs
+}
+
+// Baseline reads the current baseline values from the sensor's on-chip baseline algorithm
+func (s *Sensor) Baseline(ctx context.Context) (*Baseline, error) {
+	command := &getBaselineRequest{request: newRequest()}
+	err := s.execute(ctx, command, command.request)
+	if err != nil {
+		return nil, err
+	}
+	return command.baseline, nil
+}
+
+// SetBaseline restores previously-read baseline values to the sensor's on-chip baseline algorithm
+func (s *Sensor) SetBaseline(ctx context.Context, baseline *Baseline) error {
+	command := &setBaselineRequest{request: newRequest(), baseline: baseline}
+	return s.execute(ctx, command, command.request)
+}
+
+// TVOCInceptiveBaseline reads the TVOC baseline the sensor would use in clean air, for sensors whose feature set supports it
+func (s *Sensor) TVOCInceptiveBaseline(ctx context.Context) (uint16, error) {
+	command := &getTVOCInceptiveBaselineRequest{request: newRequest()}
+	err := s.execute(ctx, command, command.request)
+	if err != nil {
+		return 0, err
+	}
+	return command.baseline, nil
+}
+
+// SetTVOCBaseline sets the TVOC baseline of the sensor's on-chip baseline algorithm, for sensors whose feature set supports it
+func (s *Sensor) SetTVOCBaseline(ctx context.Context, baseline uint16) error {
+	command := &setTVOCBaselineRequest{request: newRequest(), baseline: baseline}
+	return s.execute(ctx, command, command.request)
+}
+
+// FeatureSet is the product type and version detected when the sensor was last connected, or nil if it has not been connected
+func (s *Sensor) FeatureSet() *FeatureSet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.featureSet
+}
+
+func (s *Sensor) setFeatureSet(featureSet *FeatureSet) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.featureSet = featureSet
 }
 
-func (s *Sensor) HandleRelativeHumidity(ctx context.Context, relativeHumidity *units.RelativeHumidity) error {
-	select {
-	case <-ctx.Done():
-	case s.commands <- relativeHumidity:
+func (s *Sensor) setSerialID(serialID uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.serialID = serialID
+}
+
+func (s *Sensor) requireProductVersion(minimumVersion uint8) error {
+	featureSet := s.FeatureSet()
+	if featureSet == nil || !featureSet.supports(minimumVersion) {
+		return errors.Wrapf(ErrUnsupported, "requires product version %#02x", minimumVersion)
 	}
 	return nil
 }
 
+// SelfTest runs the sensor's on-chip self test, then reinitializes the baseline algorithm with the baseline in effect before the test
+func (s *Sensor) SelfTest(ctx context.Context) error {
+	command := &selfTestRequest{request: newRequest()}
+	return s.execute(ctx, command, command.request)
+}
+
+// SerialID reads the 48-bit serial ID that uniquely identifies the sensor
+func (s *Sensor) SerialID(ctx context.Context) (uint64, error) {
+	command := &serialIDRequest{request: newRequest()}
+	err := s.execute(ctx, command, command.request)
+	if err != nil {
+		return 0, err
+	}
+	return command.serialID, nil
+}
+
+// request is a command whose outcome is reported back to the caller once it has been handled
+type request struct {
+	done chan error
+}
+
+func newRequest() request {
+	return request{done: make(chan error, 1)}
+}
+
+func (r request) complete(err error) {
+	r.done <- err
+}
+
+type getBaselineRequest struct {
+	request
+	baseline *Baseline
+}
+
+type setBaselineRequest struct {
+	request
+	baseline *Baseline
+}
+
+type getTVOCInceptiveBaselineRequest struct {
+	request
+	baseline uint16
+}
+
+type setTVOCBaselineRequest struct {
+	request
+	baseline uint16
+}
+
+type serialIDRequest struct {
+	request
+	serialID uint64
+}
+
+type resetRequest struct {
+	request
+}
+
+type selfTestRequest struct {
+	request
+}
+
 type requestAirQuality struct{}
 
-func requestAirQualityRepeatedly(
-	ctx context.Context,
-	commands chan interface{}) func() error {
-	request := &requestAirQuality{}
-	return func() error {
-		for {
-			select {
-			case <-ctx.Done():
-				return nil
-			case <-time.After(measureAirQualityInterval):
-				select {
-				case <-ctx.Done():
-					return nil
-				case commands <- request:
-				}
-			}
-		}
+type requestRawSignals struct{}
+
+// selfTest runs the on-chip self test, which resets the baseline algorithm, and then restores the algorithm's prior state
+func (s *Sensor) selfTest(ctx context.Context, port coreio.Port) error {
+	status := s.Status()
+	baseline, err := getBaseline(ctx, port)
+	if err != nil {
+		return errors.Wrap(err, "failed to get baseline")
+	}
+	if baseline == nil {
+		return nil
+	}
+
+	testErr := measureTest(ctx, port)
+	if testErr != nil && !errors.Is(testErr, ErrSelfTestFailed) {
+		return errors.Wrap(testErr, "failed to run self test")
+	}
+
+	err = initAirQuality(ctx, port)
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize sensor")
+	}
+	s.markInitialized()
+
+	err = setBaseline(ctx, port, baseline)
+	if err != nil {
+		return errors.Wrap(err, "failed to set baseline")
 	}
+	if !status.EarlyOperationPhase {
+		s.markBaselineRestored()
+	}
+	s.rememberBaseline(baseline)
+
+	return testErr
 }
 
-func handleCommands(
+func (s *Sensor) handleCommands(
 	ctx context.Context,
-	commands chan interface{},
-	gases chan *gas.Concentration,
 	port coreio.Port) func() error {
 	return func() error {
 		for {
 			select {
 			case <-ctx.Done():
 				return nil
-			case c := <-commands:
+			case c := <-s.commands:
 				switch command := c.(type) {
-				case *units.RelativeHumidity:
-					err := setHumidity(ctx, port, command.AbsoluteHumidity())
+				case *setAbsoluteHumidity:
+					err := setHumidity(ctx, port, command.fixedPointValue)
 					if err != nil {
 						return errors.Wrap(err, "failed to set humidity")
 					}
-				case *requestAirQuality:
-					readings, err := measureAirQuality(ctx, port)
+				case *disableHumidityCompensation:
+					// An absolute humidity of zero disables compensation
+					err := setHumidity(ctx, port, 0)
 					if err != nil {
-						return errors.Wrap(err, "failed to measure air quality")
+						return errors.Wrap(err, "failed to disable humidity compensation")
+					}
+				case *getBaselineRequest:
+					baseline, err := getBaseline(ctx, port)
+					if err != nil {
+						err = errors.Wrap(err, "failed to get baseline")
+						command.complete(err)
+						return err
+					}
+					if baseline == nil {
+						command.complete(ctx.Err())
+						return nil
 					}
 
-					tvoc := &gas.Concentration{
-						Gas:    TotalVolatileOrganicCompounds,
-						Amount: readings.TVOC,
+					s.rememberBaseline(baseline)
+					command.baseline = baseline
+					command.complete(nil)
+				case *setBaselineRequest:
+					err := setBaseline(ctx, port, command.baseline)
+					if err != nil {
+						err = errors.Wrap(err, "failed to set baseline")
+						command.complete(err)
+						return err
+					}
+					s.markBaselineRestored()
+					s.rememberBaseline(command.baseline)
+					command.complete(nil)
+				case *getTVOCInceptiveBaselineRequest:
+					err := s.requireProductVersion(tvocBaselineProductVersion)
+					if err != nil {
+						command.complete(err)
+						continue
 					}
 
-					select {
-					case <-ctx.Done():
+					baseline, err := getTVOCInceptiveBaseline(ctx, port)
+					if err != nil {
+						err = errors.Wrap(err, "failed to get TVOC inceptive baseline")
+						command.complete(err)
+						return err
+					}
+					if baseline == nil {
+						command.complete(ctx.Err())
 						return nil
-					case gases <- tvoc:
 					}
 
-					co2eq := &gas.Concentration{
-						Gas:    CarbonDioxideEquivalent,
-						Amount: readings.CO2eq,
+					command.baseline = *baseline
+					command.complete(nil)
+				case *setTVOCBaselineRequest:
+					err := s.requireProductVersion(tvocBaselineProductVersion)
+					if err != nil {
+						command.complete(err)
+						continue
 					}
 
-					select {
-					case <-ctx.Done():
+					err = setTVOCBaseline(ctx, port, command.baseline)
+					if err != nil {
+						err = errors.Wrap(err, "failed to set TVOC baseline")
+						command.complete(err)
+						return err
+					}
+					command.complete(nil)
+				case *serialIDRequest:
+					serialID, err := getSerialID(ctx, port)
+					if err != nil {
+						err = errors.Wrap(err, "failed to get serial ID")
+						command.complete(err)
+						return err
+					}
+					if ctx.Err() != nil {
+						command.complete(ctx.Err())
 						return nil
-					case gases <- co2eq:
 					}
+
+					command.serialID = serialID
+					command.complete(nil)
+				case *resetRequest:
+					if s.generalCallPortFactory == nil {
+						command.complete(errors.Wrap(ErrUnsupported, "no general call port configured"))
+						continue
+					}
+
+					err := generalCallReset(ctx, s.generalCallPortFactory)
+					if err != nil {
+						err = errors.Wrap(err, "failed to reset sensor")
+						command.complete(err)
+						return err
+					}
+
+					_, err = s.initialize(ctx, port)
+					if err != nil {
+						command.complete(err)
+						return err
+					}
+					command.complete(ctx.Err())
+				case *selfTestRequest:
+					err := s.selfTest(ctx, port)
+					if ctx.Err() != nil {
+						command.complete(ctx.Err())
+						return nil
+					}
+					if err != nil && !errors.Is(err, ErrSelfTestFailed) {
+						command.complete(err)
+						return err
+					}
+					command.complete(err)
+				case *requestRawSignals:
+					signals, err := measureRawSignals(ctx, port)
+					if err != nil {
+						return errors.Wrap(err, "failed to measure raw signals")
+					}
+					if signals == nil {
+						return nil
+					}
+
+					if s.rawSignalsEnabled {
+						s.publish(signals)
+					}
+
+					concentrations := []*gas.Concentration{}
+					if s.hydrogenReference != nil {
+						concentrations = append(concentrations, &gas.Concentration{
+							Gas:    Hydrogen,
+							Amount: s.hydrogenReference.convert(signals.H2),
+						})
+					}
+					if s.ethanolReference != nil {
+						concentrations = append(concentrations, &gas.Concentration{
+							Gas:    Ethanol,
+							Amount: s.ethanolReference.convert(signals.Ethanol),
+						})
+					}
+					for _, concentration := range s.decimateConcentrations(concentrations) {
+						s.publish(concentration)
+					}
+				case *requestAirQuality:
+					s.recordMeasurement(s.measurementInterval)
+					readings, err := measureAirQuality(ctx, port)
+					if err != nil {
+						return errors.Wrap(err, "failed to measure air quality")
+					}
+					if readings == nil {
+						return nil
+					}
+					if s.warmUpPolicy == WarmUpDrop && s.warmingUp() {
+						continue
+					}
+
+					reading := s.newReading(readings)
+					s.setLatest(reading)
+
+					reading = s.decimateReading(reading)
+					if reading == nil {
+						continue
+					}
+
+					s.publish(reading)
 				}
 			}
 		}
@@ -3,6 +3,7 @@ package sensironsgp30_test
 import (
 	"context"
 	"errors"
+	"math"
 	"testing"
 	"time"
 
@@ -28,6 +29,18 @@ func Test_NewSensor_returns_a_configured_sensor(t *testing.T) {
 	assert.NotNil(t, sensor)
 	assert.Equal(t, sensironsgp30.DefaultReconnectTimeout, sensor.ReconnectTimeout())
 	assert.Nil(t, sensor.RecoverableErrorHandler())
+	assert.Nil(t, sensor.BaselineStore())
+	assert.Nil(t, sensor.BaselineHandler())
+	assert.Equal(t, sensironsgp30.DefaultBaselineInterval, sensor.BaselineInterval())
+	assert.Equal(t, sensironsgp30.WarmUpPassThrough, sensor.WarmUpPolicy())
+	assert.Equal(t, sensironsgp30.DefaultMeasurementInterval, sensor.MeasurementInterval())
+	assert.Equal(t, 1, sensor.OutputDecimation())
+	assert.Nil(t, sensor.HumiditySource())
+	assert.Equal(t, sensironsgp30.DefaultHumidityRateLimit, sensor.HumidityRateLimit())
+	assert.Equal(t, sensironsgp30.DefaultHumidityStaleTimeout, sensor.HumidityStaleTimeout())
+	assert.Equal(t, sensironsgp30.DefaultOutputBufferSize, sensor.OutputBufferSize())
+	assert.Equal(t, &sensironsgp30.Status{}, sensor.Status())
+	assert.Equal(t, &sensironsgp30.DeliveryStats{}, sensor.DeliveryStats())
 }
 
 func Test_NewSensor_with_options_returns_a_configured_sensor(t *testing.T) {
@@ -35,17 +48,41 @@ func Test_NewSensor_with_options_returns_a_configured_sensor(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	portFactory := mocks.NewMockPortFactory(ctrl)
 	expectedReconnectTimeout := sensironsgp30.DefaultReconnectTimeout * 10
+	expectedBaselineInterval := sensironsgp30.DefaultBaselineInterval * 2
 
 	// Act
 	sensor := sensironsgp30.NewSensor(portFactory,
 		sensironsgp30.WithReconnectTimeout(expectedReconnectTimeout),
-		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }),
+		sensironsgp30.WithBaselineHandler(func(*sensironsgp30.Baseline) {}),
+		sensironsgp30.WithBaselineInterval(expectedBaselineInterval),
+		sensironsgp30.WithWarmUpPolicy(sensironsgp30.WarmUpFlag))
 
 	// Assert
 	assert.NotNil(t, sensor)
 	assert.Equal(t, expectedReconnectTimeout, sensor.ReconnectTimeout())
 	assert.NotNil(t, sensor.RecoverableErrorHandler())
 	assert.True(t, sensor.RecoverableErrorHandler()(nil))
+	assert.NotNil(t, sensor.BaselineHandler())
+	assert.Equal(t, expectedBaselineInterval, sensor.BaselineInterval())
+	assert.Equal(t, sensironsgp30.WarmUpFlag, sensor.WarmUpPolicy())
+}
+
+func Test_NewSensor_with_non_positive_durations_uses_defaults(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	// Act
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithMeasurementInterval(0),
+		sensironsgp30.WithBaselineInterval(-time.Second),
+		sensironsgp30.WithHumidityStaleTimeout(0))
+
+	// Assert
+	assert.Equal(t, sensironsgp30.DefaultMeasurementInterval, sensor.MeasurementInterval())
+	assert.Equal(t, sensironsgp30.DefaultBaselineInterval, sensor.BaselineInterval())
+	assert.Equal(t, sensironsgp30.DefaultHumidityStaleTimeout, sensor.HumidityStaleTimeout())
 }
 
 func Test_ConcentrationSpecs_returns_supported_concentrations(t *testing.T) {
@@ -139,6 +176,8 @@ func Test_Run_fails_to_initialize_sensor(t *testing.T) {
 		Open().
 		Return(port, nil)
 
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
 	port.EXPECT().
 		Write([]byte{0x20, 0x03}).
 		Return(0, errors.New("boom"))
@@ -173,6 +212,8 @@ func Test_handleCommand_fails_to_request_air_quality(t *testing.T) {
 		Open().
 		Return(port, nil)
 
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
 	port.EXPECT().
 		Write([]byte{0x20, 0x03}).
 		Return(0, nil)
@@ -210,6 +251,8 @@ func Test_handleCommand_fails_to_read_air_quality(t *testing.T) {
 		Open().
 		Return(port, nil)
 
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
 	port.EXPECT().
 		Write([]byte{0x20, 0x03}).
 		Return(0, nil)
@@ -238,6 +281,10 @@ func Test_handleCommand_fails_to_read_air_quality(t *testing.T) {
 
 	// Assert
 	assert.ErrorContains(t, err, "failed to read air quality")
+	var busErr *sensironsgp30.BusError
+	assert.ErrorAs(t, err, &busErr)
+	assert.Equal(t, uint16(0x2008), busErr.Command)
+	assert.EqualError(t, busErr.Err, "boom")
 }
 
 func Test_handleCommand_handles_bad_CRC_while_reading_air_quality(t *testing.T) {
@@ -250,6 +297,8 @@ func Test_handleCommand_handles_bad_CRC_while_reading_air_quality(t *testing.T)
 		Open().
 		Return(port, nil)
 
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
 	port.EXPECT().
 		Write([]byte{0x20, 0x03}).
 		Return(0, nil)
@@ -287,6 +336,56 @@ func Test_handleCommand_handles_bad_CRC_while_reading_air_quality(t *testing.T)
 
 	// Assert
 	assert.ErrorContains(t, err, "failed to validate crc")
+	var crcErr *sensironsgp30.CRCError
+	assert.ErrorAs(t, err, &crcErr)
+	assert.Equal(t, uint16(0x0102), crcErr.Word)
+	assert.Equal(t, uint8(0x00), crcErr.Expected)
+	assert.Equal(t, crc8.Checksum([]byte{0x01, 0x02}, checksumTable), crcErr.Actual)
+}
+
+func Test_handleCommand_handles_short_read_while_reading_air_quality(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	port.EXPECT().
+		Write([]byte{0x20, 0x08}).
+		Return(0, nil)
+	port.EXPECT().
+		Read(gomock.Any()).
+		Return(3, nil)
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.ErrorIs(t, err, sensironsgp30.ErrShortRead)
+	var busErr *sensironsgp30.BusError
+	assert.ErrorAs(t, err, &busErr)
+	assert.Equal(t, uint16(0x2008), busErr.Command)
 }
 
 var (
@@ -311,6 +410,8 @@ func Test_handleCommand_returns_expected_measurement(t *testing.T) {
 		Open().
 		Return(port, nil)
 
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
 	port.EXPECT().
 		Write([]byte{0x20, 0x03}).
 		Return(0, nil)
@@ -429,6 +530,8 @@ func Test_handleCommand_fails_to_set_humidity(t *testing.T) {
 		Open().
 		Return(port, nil)
 
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
 	port.EXPECT().
 		Write([]byte{0x20, 0x03}).
 		Return(0, nil)
@@ -436,7 +539,7 @@ func Test_handleCommand_fails_to_set_humidity(t *testing.T) {
 		Temperature: 25 * units.DegreeCelsius,
 		Percentage:  0.5,
 	}
-	fixedPointValue := uint16(expectedRelativeHumidity.AbsoluteHumidity().GramsPerCubicMeter() * 256)
+	fixedPointValue := uint16(math.Round(expectedRelativeHumidity.AbsoluteHumidity().GramsPerCubicMeter() * 256))
 	humidityData := []byte{byte(fixedPointValue >> 8), byte(fixedPointValue)}
 	humidityCRC := crc8.Checksum(humidityData, checksumTable)
 	port.EXPECT().
@@ -455,6 +558,7 @@ func Test_handleCommand_fails_to_set_humidity(t *testing.T) {
 
 	// Act
 	group.Go(func() error {
+		waitUntilInitialized(ctx, sensor)
 		return sensor.HandleRelativeHumidity(ctx, &expectedRelativeHumidity)
 	})
 	group.Go(func() error {
@@ -465,3 +569,2528 @@ func Test_handleCommand_fails_to_set_humidity(t *testing.T) {
 	// Assert
 	assert.ErrorContains(t, err, "failed to set humidity")
 }
+
+func Test_HandleAbsoluteHumidity_rejects_invalid_humidity(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+	sensor := sensironsgp30.NewSensor(portFactory)
+
+	cases := []units.MassConcentration{
+		0,
+		-1 * units.GramPerCubicMeter,
+		256 * units.GramPerCubicMeter,
+		units.MassConcentration(math.NaN()),
+		units.MassConcentration(math.Inf(1)),
+	}
+
+	for _, absoluteHumidity := range cases {
+		// Act
+		err := sensor.HandleAbsoluteHumidity(context.Background(), absoluteHumidity)
+
+		// Assert
+		assert.ErrorIs(t, err, sensironsgp30.ErrInvalidHumidity)
+	}
+}
+
+func Test_HandleAbsoluteHumidity_rounds_to_nearest_fixed_point_value(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	// 11.5 g/m³ less a fraction of the least significant bit is 0x0B80 once rounded, but 0x0B7F once truncated
+	humidityData := []byte{0x0B, 0x80}
+	disabledData := []byte{0x00, 0x00}
+
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	gomock.InOrder(
+		port.EXPECT().
+			Write([]byte{0x20, 0x03}).
+			Return(0, nil),
+		port.EXPECT().
+			Write([]byte{0x20, 0x61, humidityData[0], humidityData[1], crc8.Checksum(humidityData, checksumTable)}).
+			Return(0, nil),
+		port.EXPECT().
+			Write([]byte{0x20, 0x61, disabledData[0], disabledData[1], crc8.Checksum(disabledData, checksumTable)}).
+			DoAndReturn(func(buf []byte) (int, error) {
+				cancel()
+				return len(buf), nil
+			}),
+	)
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	group.Go(func() error {
+		waitUntilInitialized(ctx, sensor)
+		err := sensor.HandleAbsoluteHumidity(ctx, units.MassConcentration(11.5-0.001)*units.GramPerCubicMeter)
+		if err != nil {
+			return err
+		}
+		return sensor.DisableHumidityCompensation(ctx)
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+}
+
+func Test_Baseline_returns_baseline_from_sensor(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	port.EXPECT().
+		Write([]byte{0x20, 0x15}).
+		Return(0, nil)
+
+	expected := &sensironsgp30.Baseline{
+		CO2eq: 0x8F3A,
+		TVOC:  0x9122,
+	}
+	port.EXPECT().
+		Read(gomock.Any()).
+		DoAndReturn(func(buf []byte) (int, error) {
+			buf[0] = byte(expected.CO2eq >> 8)              // CO2eq baseline MSB
+			buf[1] = byte(expected.CO2eq)                   // CO2eq baseline LSB
+			buf[2] = crc8.Checksum(buf[0:2], checksumTable) // CO2eq baseline CRC
+			buf[3] = byte(expected.TVOC >> 8)               // TVOC baseline MSB
+			buf[4] = byte(expected.TVOC)                    // TVOC baseline LSB
+			buf[5] = crc8.Checksum(buf[3:5], checksumTable) // TVOC baseline CRC
+
+			return len(buf), nil
+		})
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	var actual *sensironsgp30.Baseline
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	group.Go(func() error {
+		defer cancel()
+		waitUntilInitialized(ctx, sensor)
+
+		var err error
+		actual, err = sensor.Baseline(ctx)
+		return err
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+	status := sensor.Status()
+	assert.False(t, status.InitializedAt.IsZero())
+	assert.False(t, status.BaselineRestored)
+	assert.True(t, status.EarlyOperationPhase)
+}
+
+func Test_SetBaseline_writes_baseline_to_sensor(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+
+	baseline := &sensironsgp30.Baseline{
+		CO2eq: 0x8F3A,
+		TVOC:  0x9122,
+	}
+	tvocData := []byte{byte(baseline.TVOC >> 8), byte(baseline.TVOC)}
+	co2eqData := []byte{byte(baseline.CO2eq >> 8), byte(baseline.CO2eq)}
+	port.EXPECT().
+		Write([]byte{
+			0x20, 0x1e,
+			tvocData[0], tvocData[1], crc8.Checksum(tvocData, checksumTable),
+			co2eqData[0], co2eqData[1], crc8.Checksum(co2eqData, checksumTable),
+		}).
+		Return(0, nil)
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	group.Go(func() error {
+		defer cancel()
+		waitUntilInitialized(ctx, sensor)
+		return sensor.SetBaseline(ctx, baseline)
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+}
+
+type fakeBaselineStore struct {
+	baseline *sensironsgp30.Baseline
+	err      error
+	saveErr  error
+}
+
+func (f *fakeBaselineStore) Load(ctx context.Context, serialID uint64) (*sensironsgp30.Baseline, error) {
+	return f.baseline, f.err
+}
+
+func (f *fakeBaselineStore) Save(ctx context.Context, serialID uint64, baseline *sensironsgp30.Baseline) error {
+	if f.saveErr != nil {
+		return f.saveErr
+	}
+	f.baseline = baseline
+	return f.err
+}
+
+func expectSerialID(port *mocks.MockPort, serialID uint64) *gomock.Call {
+	port.EXPECT().
+		Write([]byte{0x36, 0x82}).
+		Return(0, nil)
+	return port.EXPECT().
+		Read(gomock.Any()).
+		DoAndReturn(func(buf []byte) (int, error) {
+			for idx := 0; idx < 3; idx++ {
+				word := uint16(serialID >> (32 - 16*idx))
+				buf[idx*3] = byte(word >> 8)
+				buf[idx*3+1] = byte(word)
+				buf[idx*3+2] = crc8.Checksum(buf[idx*3:idx*3+2], checksumTable)
+			}
+			return len(buf), nil
+		})
+}
+
+func Test_Run_restores_baseline_from_store(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	store := &fakeBaselineStore{
+		baseline: &sensironsgp30.Baseline{
+			CO2eq: 0x8F3A,
+			TVOC:  0x9122,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	tvocData := []byte{0x91, 0x22}
+	co2eqData := []byte{0x8F, 0x3A}
+	port.EXPECT().
+		Write([]byte{
+			0x20, 0x1e,
+			tvocData[0], tvocData[1], crc8.Checksum(tvocData, checksumTable),
+			co2eqData[0], co2eqData[1], crc8.Checksum(co2eqData, checksumTable),
+		}).
+		DoAndReturn(func(buf []byte) (int, error) {
+			cancel()
+			return len(buf), nil
+		})
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithBaselineStore(store),
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, store, sensor.BaselineStore())
+	status := sensor.Status()
+	assert.False(t, status.InitializedAt.IsZero())
+	assert.True(t, status.BaselineRestored)
+	assert.False(t, status.EarlyOperationPhase)
+}
+
+func Test_Run_fails_to_load_baseline_from_store(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	store := &fakeBaselineStore{err: errors.New("boom")}
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithBaselineStore(store),
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.ErrorContains(t, err, "failed to load baseline")
+}
+
+func Test_Run_hands_periodic_baseline_snapshot_to_handler(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	port.EXPECT().
+		Write([]byte{0x20, 0x15}).
+		Return(0, nil)
+
+	expected := &sensironsgp30.Baseline{
+		CO2eq: 0x8F3A,
+		TVOC:  0x9122,
+	}
+	port.EXPECT().
+		Read(gomock.Any()).
+		DoAndReturn(func(buf []byte) (int, error) {
+			buf[0] = byte(expected.CO2eq >> 8)
+			buf[1] = byte(expected.CO2eq)
+			buf[2] = crc8.Checksum(buf[0:2], checksumTable)
+			buf[3] = byte(expected.TVOC >> 8)
+			buf[4] = byte(expected.TVOC)
+			buf[5] = crc8.Checksum(buf[3:5], checksumTable)
+
+			return len(buf), nil
+		})
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var actual *sensironsgp30.Baseline
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithBaselineInterval(100*time.Millisecond),
+		sensironsgp30.WithBaselineHandler(func(baseline *sensironsgp30.Baseline) {
+			actual = baseline
+			cancel()
+		}),
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+	status := sensor.Status()
+	assert.False(t, status.InitializedAt.IsZero())
+	assert.False(t, status.BaselineRestored)
+	assert.True(t, status.EarlyOperationPhase)
+}
+
+func Test_Run_reports_failure_to_save_baseline_without_reconnecting(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	baseline := &sensironsgp30.Baseline{
+		CO2eq: 0x8F3A,
+		TVOC:  0x9122,
+	}
+	tvocData := []byte{byte(baseline.TVOC >> 8), byte(baseline.TVOC)}
+	co2eqData := []byte{byte(baseline.CO2eq >> 8), byte(baseline.CO2eq)}
+
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	port.EXPECT().
+		Write([]byte{
+			0x20, 0x1e,
+			tvocData[0], tvocData[1], crc8.Checksum(tvocData, checksumTable),
+			co2eqData[0], co2eqData[1], crc8.Checksum(co2eqData, checksumTable),
+		}).
+		Return(0, nil)
+	port.EXPECT().
+		Write([]byte{0x20, 0x15}).
+		Return(0, nil).
+		Times(2)
+	expectWords(port, baseline.CO2eq, baseline.TVOC).
+		Times(2)
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	store := &fakeBaselineStore{baseline: baseline, saveErr: errors.New("boom")}
+	var errs []error
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithBaselineStore(store),
+		sensironsgp30.WithBaselineInterval(100*time.Millisecond),
+		sensironsgp30.WithMeasurementInterval(time.Hour),
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool {
+			errs = append(errs, err)
+			if len(errs) == 2 {
+				cancel()
+			}
+			return false
+		}))
+
+	// Act
+	err := sensor.Run(ctx)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.GreaterOrEqual(t, len(errs), 2)
+	assert.ErrorContains(t, errs[0], "failed to save baseline")
+	assert.ErrorContains(t, errs[1], "failed to save baseline")
+}
+
+func Test_Run_returns_failure_to_save_baseline_when_handler_terminates(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	baseline := &sensironsgp30.Baseline{
+		CO2eq: 0x8F3A,
+		TVOC:  0x9122,
+	}
+	tvocData := []byte{byte(baseline.TVOC >> 8), byte(baseline.TVOC)}
+	co2eqData := []byte{byte(baseline.CO2eq >> 8), byte(baseline.CO2eq)}
+
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	port.EXPECT().
+		Write([]byte{
+			0x20, 0x1e,
+			tvocData[0], tvocData[1], crc8.Checksum(tvocData, checksumTable),
+			co2eqData[0], co2eqData[1], crc8.Checksum(co2eqData, checksumTable),
+		}).
+		Return(0, nil)
+	port.EXPECT().
+		Write([]byte{0x20, 0x15}).
+		Return(0, nil)
+	expectWords(port, baseline.CO2eq, baseline.TVOC)
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	store := &fakeBaselineStore{baseline: baseline, saveErr: errors.New("boom")}
+	var errs []error
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithBaselineStore(store),
+		sensironsgp30.WithBaselineInterval(100*time.Millisecond),
+		sensironsgp30.WithMeasurementInterval(time.Hour),
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool {
+			errs = append(errs, err)
+			return true
+		}))
+
+	// Act
+	err := sensor.Run(ctx)
+
+	// Assert
+	assert.ErrorContains(t, err, "failed to save baseline")
+	assert.ErrorContains(t, err, "boom")
+	assert.Equal(t, 1, len(errs))
+}
+
+func Test_handleCommand_drops_readings_while_warming_up(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	port.EXPECT().
+		Write([]byte{0x20, 0x08}).
+		Return(0, nil)
+	port.EXPECT().
+		Read(gomock.Any()).
+		DoAndReturn(func(buf []byte) (int, error) {
+			buf[0] = 0x01 // CO2eq MSB
+			buf[1] = 0x90 // CO2eq LSB
+			buf[2] = crc8.Checksum(buf[0:2], checksumTable)
+			buf[3] = 0x00 // TVOC MSB
+			buf[4] = 0x00 // TVOC LSB
+			buf[5] = crc8.Checksum(buf[3:5], checksumTable)
+
+			return len(buf), nil
+		})
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithWarmUpPolicy(sensironsgp30.WarmUpDrop),
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancel()
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	group.Go(func() error {
+		select {
+		case <-ctx.Done():
+		case concentration, ok := <-sensor.Concentrations():
+			assert.False(t, ok, "received concentration while warming up", concentration)
+		}
+		return nil
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.False(t, sensor.Status().WarmingUp)
+}
+
+func Test_handleCommand_returns_expected_raw_signals(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	expected := &sensironsgp30.RawSignals{
+		H2:      13119,
+		Ethanol: 18472,
+	}
+	var lastCommand []byte
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	port.EXPECT().
+		Write([]byte{0x20, 0x08}).
+		DoAndReturn(func(buf []byte) (int, error) {
+			lastCommand = buf
+			return len(buf), nil
+		}).
+		MaxTimes(1)
+	port.EXPECT().
+		Write([]byte{0x20, 0x50}).
+		DoAndReturn(func(buf []byte) (int, error) {
+			lastCommand = buf
+			return len(buf), nil
+		})
+	port.EXPECT().
+		Read(gomock.Any()).
+		DoAndReturn(func(buf []byte) (int, error) {
+			words := []uint16{400, 0}
+			if lastCommand[1] == 0x50 {
+				words = []uint16{expected.H2, expected.Ethanol}
+			}
+			for idx, word := range words {
+				buf[idx*3] = byte(word >> 8)
+				buf[idx*3+1] = byte(word)
+				buf[idx*3+2] = crc8.Checksum(buf[idx*3:idx*3+2], checksumTable)
+			}
+			return len(buf), nil
+		}).
+		MinTimes(1).
+		MaxTimes(2)
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithRawSignals(true),
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	group.Go(func() error {
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-sensor.Concentrations():
+			}
+		}
+	})
+	group.Go(func() error {
+		select {
+		case actual, ok := <-sensor.RawSignals():
+			assert.True(t, ok)
+			assert.Equal(t, expected, actual)
+		case <-time.After(3 * time.Second):
+			assert.Fail(t, "failed to receive raw signals in expected amount of time")
+		}
+
+		cancel()
+		return nil
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.True(t, sensor.RawSignalsEnabled())
+}
+
+func Test_Run_keeps_delivering_readings_while_raw_signals_are_not_received(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	port.EXPECT().
+		Write([]byte{0x20, 0x08}).
+		Return(0, nil).
+		AnyTimes()
+	port.EXPECT().
+		Write([]byte{0x20, 0x50}).
+		Return(0, nil).
+		AnyTimes()
+	expectWords(port, 450, 25).
+		AnyTimes()
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithRawSignals(true),
+		sensironsgp30.WithMeasurementInterval(10*time.Millisecond),
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	subscription := sensor.Subscribe(ctx, nil)
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	received := 0
+	group.Go(func() error {
+		defer cancel()
+
+		for received < 20 {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-subscription.Readings():
+				received++
+			}
+		}
+		return nil
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 20, received)
+	assert.Greater(t, sensor.DeliveryStats().RawSignalsDropped, uint64(0))
+}
+
+func Test_ConcentrationSpecs_includes_converted_raw_signals(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithHydrogenReference(&sensironsgp30.SignalReference{Signal: 13119, Concentration: 500 * units.PartPerBillion}),
+		sensironsgp30.WithEthanolReference(&sensironsgp30.SignalReference{Signal: 18472, Concentration: 400 * units.PartPerBillion}))
+	expected := []*gas.ConcentrationSpec{
+		{
+			Gas:              sensironsgp30.Hydrogen,
+			Resolution:       1 * units.PartPerBillion,
+			MinConcentration: 300 * units.PartPerBillion,
+			MaxConcentration: 1000 * units.PartPerMillion,
+		},
+		{
+			Gas:              sensironsgp30.Ethanol,
+			Resolution:       1 * units.PartPerBillion,
+			MinConcentration: 300 * units.PartPerBillion,
+			MaxConcentration: 1000 * units.PartPerMillion,
+		},
+	}
+
+	// Act
+	actual := sensor.ConcentrationSpecs()
+
+	// Assert
+	assert.Len(t, actual, 9)
+	assert.EqualValues(t, expected, actual[7:])
+}
+
+func Test_handleCommand_returns_converted_raw_signals(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	hydrogenReference := &sensironsgp30.SignalReference{Signal: 13119, Concentration: 500 * units.PartPerBillion}
+	ethanolReference := &sensironsgp30.SignalReference{Signal: 18472, Concentration: 400 * units.PartPerBillion}
+	var lastCommand []byte
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	port.EXPECT().
+		Write([]byte{0x20, 0x08}).
+		DoAndReturn(func(buf []byte) (int, error) {
+			lastCommand = buf
+			return len(buf), nil
+		}).
+		MaxTimes(1)
+	port.EXPECT().
+		Write([]byte{0x20, 0x50}).
+		DoAndReturn(func(buf []byte) (int, error) {
+			lastCommand = buf
+			return len(buf), nil
+		})
+	port.EXPECT().
+		Read(gomock.Any()).
+		DoAndReturn(func(buf []byte) (int, error) {
+			words := []uint16{400, 0}
+			if lastCommand[1] == 0x50 {
+				words = []uint16{hydrogenReference.Signal, ethanolReference.Signal - 512}
+			}
+			for idx, word := range words {
+				buf[idx*3] = byte(word >> 8)
+				buf[idx*3+1] = byte(word)
+				buf[idx*3+2] = crc8.Checksum(buf[idx*3:idx*3+2], checksumTable)
+			}
+			return len(buf), nil
+		}).
+		MinTimes(1).
+		MaxTimes(2)
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithHydrogenReference(hydrogenReference),
+		sensironsgp30.WithEthanolReference(ethanolReference),
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	actual := map[string]units.Concentration{}
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	group.Go(func() error {
+		defer cancel()
+		for {
+			select {
+			case <-time.After(3 * time.Second):
+				assert.Fail(t, "failed to receive converted raw signals in expected amount of time")
+				return nil
+			case concentration := <-sensor.Concentrations():
+				actual[concentration.Gas] = concentration.Amount
+				if len(actual) == 4 || concentration.Gas == sensironsgp30.Ethanol {
+					return nil
+				}
+			}
+		}
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.False(t, sensor.RawSignalsEnabled())
+	assert.InDelta(t, 500, actual[sensironsgp30.Hydrogen].PartsPerBillion(), 0.001)
+	assert.InDelta(t, 400*math.E, actual[sensironsgp30.Ethanol].PartsPerBillion(), 0.001)
+}
+
+func expectFeatureSet(port *mocks.MockPort, featureSet uint16) *gomock.Call {
+	port.EXPECT().
+		Write([]byte{0x20, 0x2f}).
+		Return(0, nil)
+	return expectWords(port, featureSet)
+}
+
+func expectWords(port *mocks.MockPort, words ...uint16) *gomock.Call {
+	return port.EXPECT().
+		Read(gomock.Any()).
+		DoAndReturn(func(buf []byte) (int, error) {
+			for idx, word := range words {
+				buf[idx*3] = byte(word >> 8)
+				buf[idx*3+1] = byte(word)
+				buf[idx*3+2] = crc8.Checksum(buf[idx*3:idx*3+2], checksumTable)
+			}
+			return len(buf), nil
+		})
+}
+
+func waitUntilInitialized(ctx context.Context, sensor *sensironsgp30.Sensor) {
+	for sensor.Status().InitializedAt.IsZero() && ctx.Err() == nil {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func Test_SelfTest_reports_result_and_reinitializes_sensor(t *testing.T) {
+	cases := []struct {
+		name     string
+		result   uint16
+		expected error
+	}{
+		{name: "pass", result: 0xD400, expected: nil},
+		{name: "fail", result: 0x1234, expected: sensironsgp30.ErrSelfTestFailed},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			portFactory := mocks.NewMockPortFactory(ctrl)
+
+			port := mocks.NewMockPort(ctrl)
+			portFactory.EXPECT().
+				Open().
+				Return(port, nil)
+
+			tvocData := []byte{0x91, 0x22}
+			co2eqData := []byte{0x8F, 0x3A}
+			gomock.InOrder(
+				expectFeatureSet(port, 0x0022),
+				expectSerialID(port, 0x0000_0123_4567),
+				port.EXPECT().
+					Write([]byte{0x20, 0x03}).
+					Return(0, nil),
+				port.EXPECT().
+					Write([]byte{0x20, 0x15}).
+					Return(0, nil),
+				expectWords(port, 0x8F3A, 0x9122),
+				port.EXPECT().
+					Write([]byte{0x20, 0x32}).
+					Return(0, nil),
+				expectWords(port, c.result),
+				port.EXPECT().
+					Write([]byte{0x20, 0x03}).
+					Return(0, nil),
+				port.EXPECT().
+					Write([]byte{
+						0x20, 0x1e,
+						tvocData[0], tvocData[1], crc8.Checksum(tvocData, checksumTable),
+						co2eqData[0], co2eqData[1], crc8.Checksum(co2eqData, checksumTable),
+					}).
+					Return(0, nil),
+			)
+			port.EXPECT().
+				Close().
+				Return(nil)
+
+			sensor := sensironsgp30.NewSensor(portFactory,
+				sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+			defer cancel()
+			group, ctx := errgroup.WithContext(ctx)
+
+			// Act
+			var actual error
+			group.Go(func() error {
+				return sensor.Run(ctx)
+			})
+			group.Go(func() error {
+				defer cancel()
+				waitUntilInitialized(ctx, sensor)
+				actual = sensor.SelfTest(ctx)
+				return nil
+			})
+			err := group.Wait()
+
+			// Assert
+			assert.Nil(t, err)
+			if c.expected == nil {
+				assert.Nil(t, actual)
+			} else {
+				assert.ErrorIs(t, actual, c.expected)
+			}
+		})
+	}
+}
+
+func Test_SerialID_returns_serial_ID_from_sensor(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	expected := uint64(0x0000_0123_4567)
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	expectSerialID(port, expected)
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	var actual uint64
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	group.Go(func() error {
+		defer cancel()
+		waitUntilInitialized(ctx, sensor)
+
+		var err error
+		actual, err = sensor.SerialID(ctx)
+		return err
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func Test_Run_detects_feature_set(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		DoAndReturn(func(buf []byte) (int, error) {
+			cancel()
+			return len(buf), nil
+		})
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, &sensironsgp30.FeatureSet{ProductType: 0, ProductVersion: 0x22}, sensor.FeatureSet())
+}
+
+func Test_Run_fails_for_unexpected_product_type(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	expectFeatureSet(port, 0x1022)
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.ErrorIs(t, err, sensironsgp30.ErrUnsupported)
+	assert.Nil(t, sensor.FeatureSet())
+}
+
+func Test_TVOCInceptiveBaseline_returns_baseline_from_sensor(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	expected := uint16(0x9122)
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	port.EXPECT().
+		Write([]byte{0x20, 0xb3}).
+		Return(0, nil)
+	expectWords(port, expected)
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	var actual uint16
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	group.Go(func() error {
+		defer cancel()
+		waitUntilInitialized(ctx, sensor)
+
+		var err error
+		actual, err = sensor.TVOCInceptiveBaseline(ctx)
+		return err
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func Test_SetTVOCBaseline_writes_baseline_to_sensor(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	tvocData := []byte{0x91, 0x22}
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	port.EXPECT().
+		Write([]byte{0x20, 0x77, tvocData[0], tvocData[1], crc8.Checksum(tvocData, checksumTable)}).
+		Return(0, nil)
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	group.Go(func() error {
+		defer cancel()
+		waitUntilInitialized(ctx, sensor)
+		return sensor.SetTVOCBaseline(ctx, 0x9122)
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+}
+
+func Test_SetTVOCBaseline_is_unsupported_by_older_feature_sets(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	expectFeatureSet(port, 0x0020)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	var actual error
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	group.Go(func() error {
+		defer cancel()
+		waitUntilInitialized(ctx, sensor)
+		actual = sensor.SetTVOCBaseline(ctx, 0x9122)
+		return nil
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.ErrorIs(t, actual, sensironsgp30.ErrUnsupported)
+}
+
+func Test_Reset_resets_and_reinitializes_sensor(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+	generalCallPortFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+	generalCallPort := mocks.NewMockPort(ctrl)
+	generalCallPortFactory.EXPECT().
+		Open().
+		Return(generalCallPort, nil)
+
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	generalCallPort.EXPECT().
+		Write([]byte{0x06}).
+		Return(1, nil)
+	generalCallPort.EXPECT().
+		Close().
+		Return(nil)
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithGeneralCallPortFactory(generalCallPortFactory),
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	group.Go(func() error {
+		defer cancel()
+		waitUntilInitialized(ctx, sensor)
+		return sensor.Reset(ctx)
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, generalCallPortFactory, sensor.GeneralCallPortFactory())
+}
+
+func Test_Run_resets_sensor_after_failure(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+	generalCallPortFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+	generalCallPort := mocks.NewMockPort(ctrl)
+	generalCallPortFactory.EXPECT().
+		Open().
+		Return(generalCallPort, nil)
+
+	port.EXPECT().
+		Write([]byte{0x20, 0x2f}).
+		Return(0, errors.New("boom"))
+	port.EXPECT().
+		Close().
+		Return(nil)
+	generalCallPort.EXPECT().
+		Write([]byte{0x06}).
+		Return(1, nil)
+	generalCallPort.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithGeneralCallPortFactory(generalCallPortFactory),
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return false }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+}
+
+func Test_Run_reports_failure_to_reset_sensor(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+	generalCallPortFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+	generalCallPort := mocks.NewMockPort(ctrl)
+	generalCallPortFactory.EXPECT().
+		Open().
+		Return(generalCallPort, nil)
+
+	port.EXPECT().
+		Write([]byte{0x20, 0x2f}).
+		Return(0, errors.New("boom"))
+	port.EXPECT().
+		Close().
+		Return(nil)
+	generalCallPort.EXPECT().
+		Write([]byte{0x06}).
+		Return(0, errors.New("bang"))
+	generalCallPort.EXPECT().
+		Close().
+		Return(nil)
+
+	var errs []error
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithGeneralCallPortFactory(generalCallPortFactory),
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool {
+			errs = append(errs, err)
+			return len(errs) == 2
+		}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	// Act
+	err := sensor.Run(ctx)
+
+	// Assert
+	assert.ErrorContains(t, err, "failed to reset sensor")
+	var busErr *sensironsgp30.BusError
+	assert.ErrorAs(t, err, &busErr)
+	assert.Equal(t, uint16(0x06), busErr.Command)
+	assert.Len(t, errs, 2)
+	assert.ErrorContains(t, errs[0], "failed to detect feature set")
+}
+
+func Test_Readings_returns_expected_reading(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	port.EXPECT().
+		Write([]byte{0x20, 0x08}).
+		Return(0, nil)
+	expectWords(port, 450, 25)
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithWarmUpPolicy(sensironsgp30.WarmUpFlag),
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	var actual *sensironsgp30.Reading
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	group.Go(func() error {
+		defer cancel()
+
+		select {
+		case actual = <-sensor.Readings():
+		case <-time.After(3 * time.Second):
+			assert.Fail(t, "failed to receive reading in expected amount of time")
+		}
+		return nil
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.NotNil(t, actual)
+	assert.Equal(t, uint64(1), actual.Sequence)
+	assert.False(t, actual.Timestamp.IsZero())
+	assert.Equal(t, uint64(0x0000_0123_4567), actual.SerialID)
+	assert.Equal(t, 25*units.PartPerBillion, actual.TVOC)
+	assert.Equal(t, 450*units.PartPerMillion, actual.CO2eq)
+	assert.True(t, actual.WarmingUp)
+	assert.True(t, actual.EarlyOperationPhase)
+}
+
+func Test_Run_measures_air_quality_at_fixed_interval(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	port.EXPECT().
+		Write([]byte{0x20, 0x08}).
+		Return(0, nil).
+		Times(2)
+	expectWords(port, 450, 25).
+		Times(2)
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	group.Go(func() error {
+		defer cancel()
+
+		for idx := 0; idx < 2; idx++ {
+			select {
+			case <-sensor.Readings():
+			case <-time.After(3 * time.Second):
+				assert.Fail(t, "failed to receive reading in expected amount of time")
+			}
+		}
+		return nil
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+	stats := sensor.MeasurementStats()
+	assert.Equal(t, uint64(2), stats.Measurements)
+	assert.InDelta(t, time.Second, stats.LastPeriod, float64(100*time.Millisecond))
+	assert.Equal(t, stats.LastJitter, stats.MaxJitter)
+	assert.Equal(t, uint64(0), stats.Overruns)
+}
+
+func Test_Readings_returns_decimated_reading(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	port.EXPECT().
+		Write([]byte{0x20, 0x08}).
+		Return(0, nil).
+		Times(3)
+	gomock.InOrder(
+		expectWords(port, 400, 10),
+		expectWords(port, 600, 30),
+		expectWords(port, 500, 50),
+	)
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithMeasurementInterval(100*time.Millisecond),
+		sensironsgp30.WithOutputDecimation(3),
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	var actual *sensironsgp30.Reading
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	group.Go(func() error {
+		defer cancel()
+
+		select {
+		case actual = <-sensor.Readings():
+		case <-time.After(3 * time.Second):
+			assert.Fail(t, "failed to receive reading in expected amount of time")
+		}
+		return nil
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 100*time.Millisecond, sensor.MeasurementInterval())
+	assert.Equal(t, 3, sensor.OutputDecimation())
+	assert.NotNil(t, actual)
+	assert.Equal(t, uint64(3), actual.Sequence)
+	assert.Equal(t, 3, actual.Samples)
+	assert.Equal(t, 30*units.PartPerBillion, actual.TVOC)
+	assert.Equal(t, sensironsgp30.Range{Min: 10 * units.PartPerBillion, Max: 50 * units.PartPerBillion}, actual.TVOCRange)
+	assert.Equal(t, 500*units.PartPerMillion, actual.CO2eq)
+	assert.Equal(t, sensironsgp30.Range{Min: 400 * units.PartPerMillion, Max: 600 * units.PartPerMillion}, actual.CO2eqRange)
+}
+
+func Test_Run_forwards_humidity_from_source_until_stale(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	relativeHumidity := units.RelativeHumidity{
+		Temperature: 25 * units.DegreeCelsius,
+		Percentage:  0.5,
+	}
+	fixedPointValue := uint16(math.Round(relativeHumidity.AbsoluteHumidity().GramsPerCubicMeter() * 256))
+	humidityData := []byte{byte(fixedPointValue >> 8), byte(fixedPointValue)}
+	disabledData := []byte{0x00, 0x00}
+
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	gomock.InOrder(
+		port.EXPECT().
+			Write([]byte{0x20, 0x03}).
+			Return(0, nil),
+		port.EXPECT().
+			Write([]byte{0x20, 0x61, humidityData[0], humidityData[1], crc8.Checksum(humidityData, checksumTable)}).
+			Return(0, nil),
+		port.EXPECT().
+			Write([]byte{0x20, 0x61, disabledData[0], disabledData[1], crc8.Checksum(disabledData, checksumTable)}).
+			DoAndReturn(func(buf []byte) (int, error) {
+				cancel()
+				return len(buf), nil
+			}),
+	)
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	source := make(chan *units.RelativeHumidity, 2)
+	source <- &relativeHumidity
+	source <- &units.RelativeHumidity{Temperature: 30 * units.DegreeCelsius, Percentage: 0.8}
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithHumiditySource(source),
+		sensironsgp30.WithHumidityRateLimit(time.Hour),
+		sensironsgp30.WithHumidityStaleTimeout(200*time.Millisecond),
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.NotNil(t, sensor.HumiditySource())
+	assert.Equal(t, time.Hour, sensor.HumidityRateLimit())
+	assert.Equal(t, 200*time.Millisecond, sensor.HumidityStaleTimeout())
+}
+
+func Test_commands_fail_before_Run(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+	sensor := sensironsgp30.NewSensor(portFactory)
+	relativeHumidity := &units.RelativeHumidity{
+		Temperature: 25 * units.DegreeCelsius,
+		Percentage:  0.5,
+	}
+
+	// Act
+	_, baselineErr := sensor.Baseline(context.Background())
+	humidityErr := sensor.HandleRelativeHumidity(context.Background(), relativeHumidity)
+
+	// Assert
+	assert.ErrorIs(t, baselineErr, sensironsgp30.ErrNotRunning)
+	assert.ErrorIs(t, humidityErr, sensironsgp30.ErrNotRunning)
+}
+
+func Test_commands_fail_after_Run(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(nil, errors.New("boom"))
+	sensor := sensironsgp30.NewSensor(portFactory)
+	relativeHumidity := &units.RelativeHumidity{
+		Temperature: 25 * units.DegreeCelsius,
+		Percentage:  0.5,
+	}
+	runErr := sensor.Run(context.Background())
+
+	// Act
+	_, baselineErr := sensor.Baseline(context.Background())
+	humidityErr := sensor.HandleRelativeHumidity(context.Background(), relativeHumidity)
+
+	// Assert
+	assert.ErrorContains(t, runErr, "failed to open port")
+	assert.ErrorIs(t, baselineErr, sensironsgp30.ErrStopped)
+	assert.ErrorIs(t, humidityErr, sensironsgp30.ErrStopped)
+}
+
+func Test_Run_applies_humidity_handled_before_connecting(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var sensor *sensironsgp30.Sensor
+	relativeHumidity := &units.RelativeHumidity{
+		Temperature: 25 * units.DegreeCelsius,
+		Percentage:  0.5,
+	}
+	fixedPointValue := uint16(math.Round(relativeHumidity.AbsoluteHumidity().GramsPerCubicMeter() * 256))
+	humidityData := []byte{byte(fixedPointValue >> 8), byte(fixedPointValue)}
+
+	var humidityErr error
+	gomock.InOrder(
+		expectFeatureSet(port, 0x0022).
+			Do(func(buf []byte) {
+				humidityErr = sensor.HandleRelativeHumidity(ctx, relativeHumidity)
+			}),
+		expectSerialID(port, 0x0000_0123_4567),
+		port.EXPECT().
+			Write([]byte{0x20, 0x03}).
+			Return(0, nil),
+		port.EXPECT().
+			Write([]byte{0x20, 0x61, humidityData[0], humidityData[1], crc8.Checksum(humidityData, checksumTable)}).
+			DoAndReturn(func(buf []byte) (int, error) {
+				cancel()
+				return len(buf), nil
+			}),
+	)
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor = sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	// Act
+	err := sensor.Run(ctx)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Nil(t, humidityErr)
+}
+
+func Test_Run_replays_humidity_and_baseline_after_reconnecting(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	relativeHumidity := &units.RelativeHumidity{
+		Temperature: 25 * units.DegreeCelsius,
+		Percentage:  0.5,
+	}
+	fixedPointValue := uint16(math.Round(relativeHumidity.AbsoluteHumidity().GramsPerCubicMeter() * 256))
+	humidityData := []byte{byte(fixedPointValue >> 8), byte(fixedPointValue)}
+	humidityWrite := []byte{0x20, 0x61, humidityData[0], humidityData[1], crc8.Checksum(humidityData, checksumTable)}
+
+	baseline := &sensironsgp30.Baseline{
+		CO2eq: 0x8F3A,
+		TVOC:  0x9122,
+	}
+	tvocData := []byte{byte(baseline.TVOC >> 8), byte(baseline.TVOC)}
+	co2eqData := []byte{byte(baseline.CO2eq >> 8), byte(baseline.CO2eq)}
+	baselineWrite := []byte{
+		0x20, 0x1e,
+		tvocData[0], tvocData[1], crc8.Checksum(tvocData, checksumTable),
+		co2eqData[0], co2eqData[1], crc8.Checksum(co2eqData, checksumTable),
+	}
+
+	firstPort := mocks.NewMockPort(ctrl)
+	secondPort := mocks.NewMockPort(ctrl)
+	gomock.InOrder(
+		portFactory.EXPECT().
+			Open().
+			Return(firstPort, nil),
+		portFactory.EXPECT().
+			Open().
+			Return(secondPort, nil),
+	)
+
+	expectFeatureSet(firstPort, 0x0022)
+	expectSerialID(firstPort, 0x0000_0123_4567)
+	gomock.InOrder(
+		firstPort.EXPECT().
+			Write([]byte{0x20, 0x03}).
+			Return(0, nil),
+		firstPort.EXPECT().
+			Write(baselineWrite).
+			Return(0, nil),
+		firstPort.EXPECT().
+			Write(humidityWrite).
+			Return(0, errors.New("boom")),
+	)
+	firstPort.EXPECT().
+		Close().
+		Return(nil)
+
+	expectFeatureSet(secondPort, 0x0022)
+	expectSerialID(secondPort, 0x0000_0123_4567)
+	gomock.InOrder(
+		secondPort.EXPECT().
+			Write([]byte{0x20, 0x03}).
+			Return(0, nil),
+		secondPort.EXPECT().
+			Write(humidityWrite).
+			Return(0, nil),
+		secondPort.EXPECT().
+			Write(baselineWrite).
+			DoAndReturn(func(buf []byte) (int, error) {
+				cancel()
+				return len(buf), nil
+			}),
+	)
+	secondPort.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithMeasurementInterval(time.Hour),
+		sensironsgp30.WithReconnectTimeout(time.Millisecond),
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return false }))
+
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	group.Go(func() error {
+		waitUntilInitialized(ctx, sensor)
+		err := sensor.SetBaseline(ctx, baseline)
+		if err != nil {
+			return err
+		}
+		return sensor.HandleRelativeHumidity(ctx, relativeHumidity)
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.True(t, sensor.Status().BaselineRestored)
+	humidity := sensor.HumidityCompensation()
+	assert.NotNil(t, humidity)
+	assert.InDelta(t, relativeHumidity.AbsoluteHumidity().GramsPerCubicMeter(), humidity.AbsoluteHumidity.GramsPerCubicMeter(), 1.0/256)
+	assert.Less(t, humidity.Age(), 3*time.Second)
+}
+
+func Test_Run_fails_while_already_running(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var sensor *sensironsgp30.Sensor
+	var actual error
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		DoAndReturn(func(buf []byte) (int, error) {
+			actual = sensor.Run(ctx)
+			cancel()
+			return len(buf), nil
+		})
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor = sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	// Act
+	err := sensor.Run(ctx)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.ErrorIs(t, actual, sensironsgp30.ErrAlreadyRunning)
+}
+
+func Test_Run_resumes_reading_after_returning(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	firstCtx, firstCancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer firstCancel()
+	secondCtx, secondCancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer secondCancel()
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil).
+		Times(2)
+
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		DoAndReturn(func(buf []byte) (int, error) {
+			firstCancel()
+			return len(buf), nil
+		})
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	port.EXPECT().
+		Write([]byte{0x20, 0x08}).
+		Return(0, nil)
+	expectWords(port, 450, 25)
+	port.EXPECT().
+		Close().
+		Return(nil).
+		Times(2)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+	readings := sensor.Readings()
+
+	// Act
+	firstErr := sensor.Run(firstCtx)
+	_, stoppedErr := sensor.SerialID(context.Background())
+
+	group, secondCtx := errgroup.WithContext(secondCtx)
+	var actual *sensironsgp30.Reading
+	group.Go(func() error {
+		return sensor.Run(secondCtx)
+	})
+	group.Go(func() error {
+		defer secondCancel()
+
+		select {
+		case actual = <-readings:
+		case <-time.After(3 * time.Second):
+			assert.Fail(t, "failed to receive reading in expected amount of time")
+		}
+		return nil
+	})
+	secondErr := group.Wait()
+
+	// Assert
+	assert.Nil(t, firstErr)
+	assert.ErrorIs(t, stoppedErr, sensironsgp30.ErrStopped)
+	assert.Nil(t, secondErr)
+	assert.NotNil(t, actual)
+	assert.Equal(t, 450*units.PartPerMillion, actual.CO2eq)
+}
+
+func Test_Subscribe_delivers_readings_to_each_subscriber(t *testing.T) {
+	cases := []struct {
+		name             string
+		policy           sensironsgp30.OverflowPolicy
+		expectedSequence uint64
+	}{
+		{name: "drop oldest", policy: sensironsgp30.OverflowDropOldest, expectedSequence: 3},
+		{name: "drop newest", policy: sensironsgp30.OverflowDropNewest, expectedSequence: 1},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			portFactory := mocks.NewMockPortFactory(ctrl)
+
+			port := mocks.NewMockPort(ctrl)
+			portFactory.EXPECT().
+				Open().
+				Return(port, nil)
+
+			expectFeatureSet(port, 0x0022)
+			expectSerialID(port, 0x0000_0123_4567)
+			port.EXPECT().
+				Write([]byte{0x20, 0x03}).
+				Return(0, nil)
+			port.EXPECT().
+				Write([]byte{0x20, 0x08}).
+				Return(0, nil).
+				AnyTimes()
+			expectWords(port, 450, 25).
+				AnyTimes()
+			port.EXPECT().
+				Close().
+				Return(nil)
+
+			sensor := sensironsgp30.NewSensor(portFactory,
+				sensironsgp30.WithMeasurementInterval(50*time.Millisecond),
+				sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+			defer cancel()
+			subscriptionCtx, unsubscribe := context.WithCancel(context.Background())
+			defer unsubscribe()
+
+			lagging := sensor.Subscribe(subscriptionCtx, &sensironsgp30.SubscriptionOptions{
+				BufferSize:     1,
+				OverflowPolicy: c.policy,
+			})
+			laggingReadings := lagging.Readings()
+			following := sensor.Subscribe(ctx, &sensironsgp30.SubscriptionOptions{
+				OverflowPolicy: sensironsgp30.OverflowBlock,
+			})
+
+			group, ctx := errgroup.WithContext(ctx)
+
+			// Act
+			group.Go(func() error {
+				return sensor.Run(ctx)
+			})
+			var received []*sensironsgp30.Reading
+			group.Go(func() error {
+				defer cancel()
+
+				for len(received) < 3 {
+					select {
+					case reading := <-following.Readings():
+						received = append(received, reading)
+					case <-time.After(3 * time.Second):
+						assert.Fail(t, "failed to receive reading in expected amount of time")
+						return nil
+					}
+				}
+				return nil
+			})
+			err := group.Wait()
+			unsubscribe()
+
+			// Assert
+			assert.Nil(t, err)
+			assert.Len(t, received, 3)
+			assert.Equal(t, uint64(2), lagging.Dropped())
+			assert.Equal(t, uint64(0), following.Dropped())
+
+			buffered, ok := <-laggingReadings
+			assert.True(t, ok)
+			assert.Equal(t, c.expectedSequence, buffered.Sequence)
+
+			select {
+			case _, ok := <-laggingReadings:
+				assert.False(t, ok)
+			case <-time.After(3 * time.Second):
+				assert.Fail(t, "failed to close subscription in expected amount of time")
+			}
+		})
+	}
+}
+
+func Test_Run_keeps_measuring_while_subscriber_is_stalled(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	port.EXPECT().
+		Write([]byte{0x20, 0x08}).
+		Return(0, nil).
+		AnyTimes()
+	expectWords(port, 450, 25).
+		AnyTimes()
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithMeasurementInterval(20*time.Millisecond),
+		sensironsgp30.WithOutputBufferSize(2),
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	subscriptionCtx, unsubscribe := context.WithCancel(context.Background())
+	defer unsubscribe()
+
+	stalled := sensor.Subscribe(subscriptionCtx, &sensironsgp30.SubscriptionOptions{
+		OverflowPolicy: sensironsgp30.OverflowBlock,
+	})
+	stalled.Readings()
+
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	group.Go(func() error {
+		defer cancel()
+
+		for sensor.MeasurementStats().Measurements < 6 && ctx.Err() == nil {
+			time.Sleep(time.Millisecond)
+		}
+		return nil
+	})
+	err := group.Wait()
+	stats := sensor.DeliveryStats()
+	unsubscribe()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), sensor.MeasurementStats().Overruns)
+	assert.Equal(t, uint64(0), stats.Delivered)
+	assert.Equal(t, 2, stats.Pending)
+	assert.Greater(t, stats.Overwritten, uint64(0))
+
+	for sensor.DeliveryStats().Delivered == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	assert.Greater(t, sensor.DeliveryStats().MaxLag, time.Duration(0))
+	assert.Equal(t, 2, sensor.OutputBufferSize())
+}
+
+func Test_Read_returns_next_measured_reading(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	port.EXPECT().
+		Write([]byte{0x20, 0x08}).
+		Return(0, nil).
+		AnyTimes()
+	expectWords(port, 450, 25).
+		AnyTimes()
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithMeasurementInterval(50*time.Millisecond),
+		sensironsgp30.WithOutputDecimation(10),
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	_, initiallyOK := sensor.Latest()
+	_, notRunningErr := sensor.Read(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	var actual *sensironsgp30.Reading
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	group.Go(func() error {
+		defer cancel()
+		waitUntilInitialized(ctx, sensor)
+
+		var err error
+		actual, err = sensor.Read(ctx)
+		return err
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.False(t, initiallyOK)
+	assert.ErrorIs(t, notRunningErr, sensironsgp30.ErrNotRunning)
+	assert.Nil(t, err)
+	assert.NotNil(t, actual)
+	assert.Equal(t, uint64(1), actual.Sequence)
+	assert.Equal(t, 1, actual.Samples)
+	assert.Equal(t, 25*units.PartPerBillion, actual.TVOC)
+	assert.Equal(t, 450*units.PartPerMillion, actual.CO2eq)
+
+	latest, ok := sensor.Latest()
+	assert.True(t, ok)
+	assert.Equal(t, actual, latest)
+}
+
+func Test_Subscribe_treats_negative_buffer_size_as_unbuffered(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+	sensor := sensironsgp30.NewSensor(portFactory)
+
+	// Act
+	subscription := sensor.Subscribe(context.Background(), &sensironsgp30.SubscriptionOptions{
+		BufferSize:     -1,
+		OverflowPolicy: sensironsgp30.OverflowDropNewest,
+	})
+
+	// Assert
+	assert.NotNil(t, subscription)
+	assert.Equal(t, 0, subscription.Options().BufferSize)
+	assert.Equal(t, 0, cap(subscription.Readings()))
+	assert.Equal(t, 0, cap(subscription.Concentrations()))
+}
+
+func Test_Subscribe_delivers_readings_while_shared_consumer_is_stalled(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	port.EXPECT().
+		Write([]byte{0x20, 0x08}).
+		Return(0, nil).
+		AnyTimes()
+	expectWords(port, 450, 25).
+		AnyTimes()
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithMeasurementInterval(10*time.Millisecond),
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	sensor.Concentrations()
+	sensor.Readings()
+	subscription := sensor.Subscribe(ctx, &sensironsgp30.SubscriptionOptions{
+		OverflowPolicy: sensironsgp30.OverflowBlock,
+	})
+
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	received := 0
+	group.Go(func() error {
+		defer cancel()
+
+		for received < 20 {
+			select {
+			case <-subscription.Readings():
+				received++
+			case <-time.After(time.Second):
+				assert.Fail(t, "failed to receive reading in expected amount of time")
+				return nil
+			}
+		}
+		return nil
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 20, received)
+	assert.Greater(t, sensor.DeliveryStats().SharedDropped, uint64(0))
+}
+
+func Test_Readings_returns_reading_measured_before_first_call(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	port := mocks.NewMockPort(ctrl)
+	portFactory.EXPECT().
+		Open().
+		Return(port, nil)
+
+	expectFeatureSet(port, 0x0022)
+	expectSerialID(port, 0x0000_0123_4567)
+	port.EXPECT().
+		Write([]byte{0x20, 0x03}).
+		Return(0, nil)
+	port.EXPECT().
+		Write([]byte{0x20, 0x08}).
+		Return(0, nil)
+	expectWords(port, 450, 25)
+	port.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithMeasurementInterval(100*time.Millisecond),
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return true }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	group.Go(func() error {
+		defer cancel()
+
+		for sensor.DeliveryStats().Delivered == 0 && ctx.Err() == nil {
+			time.Sleep(time.Millisecond)
+		}
+		return nil
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+	select {
+	case actual := <-sensor.Readings():
+		assert.Equal(t, uint64(1), actual.Sequence)
+	default:
+		assert.Fail(t, "failed to receive reading measured before first call")
+	}
+	select {
+	case actual := <-sensor.Concentrations():
+		assert.Equal(t, sensironsgp30.TotalVolatileOrganicCompounds, actual.Gas)
+	default:
+		assert.Fail(t, "failed to receive concentration measured before first call")
+	}
+}
+
+func Test_Run_disables_stale_humidity_replayed_after_reconnecting(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	relativeHumidity := units.RelativeHumidity{
+		Temperature: 25 * units.DegreeCelsius,
+		Percentage:  0.5,
+	}
+	fixedPointValue := uint16(math.Round(relativeHumidity.AbsoluteHumidity().GramsPerCubicMeter() * 256))
+	humidityData := []byte{byte(fixedPointValue >> 8), byte(fixedPointValue)}
+	humidityWrite := []byte{0x20, 0x61, humidityData[0], humidityData[1], crc8.Checksum(humidityData, checksumTable)}
+	disabledData := []byte{0x00, 0x00}
+
+	firstPort := mocks.NewMockPort(ctrl)
+	secondPort := mocks.NewMockPort(ctrl)
+	gomock.InOrder(
+		portFactory.EXPECT().
+			Open().
+			Return(firstPort, nil),
+		portFactory.EXPECT().
+			Open().
+			Return(secondPort, nil),
+	)
+
+	expectFeatureSet(firstPort, 0x0022)
+	expectSerialID(firstPort, 0x0000_0123_4567)
+	gomock.InOrder(
+		firstPort.EXPECT().
+			Write([]byte{0x20, 0x03}).
+			Return(0, nil),
+		firstPort.EXPECT().
+			Write(humidityWrite).
+			Return(0, errors.New("boom")),
+	)
+	firstPort.EXPECT().
+		Close().
+		Return(nil)
+
+	expectFeatureSet(secondPort, 0x0022)
+	expectSerialID(secondPort, 0x0000_0123_4567)
+	gomock.InOrder(
+		secondPort.EXPECT().
+			Write([]byte{0x20, 0x03}).
+			Return(0, nil),
+		secondPort.EXPECT().
+			Write(humidityWrite).
+			Return(0, nil),
+		secondPort.EXPECT().
+			Write([]byte{0x20, 0x61, disabledData[0], disabledData[1], crc8.Checksum(disabledData, checksumTable)}).
+			DoAndReturn(func(buf []byte) (int, error) {
+				cancel()
+				return len(buf), nil
+			}),
+	)
+	secondPort.EXPECT().
+		Close().
+		Return(nil)
+
+	source := make(chan *units.RelativeHumidity, 1)
+	source <- &relativeHumidity
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithHumiditySource(source),
+		sensironsgp30.WithHumidityRateLimit(0),
+		sensironsgp30.WithHumidityStaleTimeout(300*time.Millisecond),
+		sensironsgp30.WithMeasurementInterval(time.Hour),
+		sensironsgp30.WithReconnectTimeout(time.Millisecond),
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return false }))
+
+	// Act
+	err := sensor.Run(ctx)
+
+	// Assert
+	assert.Nil(t, err)
+	humidity := sensor.HumidityCompensation()
+	assert.NotNil(t, humidity)
+	assert.Equal(t, units.MassConcentration(0), humidity.AbsoluteHumidity)
+}
+
+func Test_Run_keeps_explicit_humidity_replayed_after_reconnecting(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	portFactory := mocks.NewMockPortFactory(ctrl)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	absoluteHumidity := 10 * units.GramPerCubicMeter
+	humidityData := []byte{0x0A, 0x00}
+	humidityWrite := []byte{0x20, 0x61, humidityData[0], humidityData[1], crc8.Checksum(humidityData, checksumTable)}
+
+	firstPort := mocks.NewMockPort(ctrl)
+	secondPort := mocks.NewMockPort(ctrl)
+	gomock.InOrder(
+		portFactory.EXPECT().
+			Open().
+			Return(firstPort, nil),
+		portFactory.EXPECT().
+			Open().
+			Return(secondPort, nil),
+	)
+
+	expectFeatureSet(firstPort, 0x0022)
+	expectSerialID(firstPort, 0x0000_0123_4567)
+	gomock.InOrder(
+		firstPort.EXPECT().
+			Write([]byte{0x20, 0x03}).
+			Return(0, nil),
+		firstPort.EXPECT().
+			Write(humidityWrite).
+			Return(0, errors.New("boom")),
+	)
+	firstPort.EXPECT().
+		Close().
+		Return(nil)
+
+	replayed := make(chan struct{})
+	expectFeatureSet(secondPort, 0x0022)
+	expectSerialID(secondPort, 0x0000_0123_4567)
+	gomock.InOrder(
+		secondPort.EXPECT().
+			Write([]byte{0x20, 0x03}).
+			Return(0, nil),
+		secondPort.EXPECT().
+			Write(humidityWrite).
+			DoAndReturn(func(buf []byte) (int, error) {
+				close(replayed)
+				return len(buf), nil
+			}),
+	)
+	secondPort.EXPECT().
+		Close().
+		Return(nil)
+
+	sensor := sensironsgp30.NewSensor(portFactory,
+		sensironsgp30.WithHumiditySource(make(chan *units.RelativeHumidity)),
+		sensironsgp30.WithHumidityStaleTimeout(100*time.Millisecond),
+		sensironsgp30.WithMeasurementInterval(time.Hour),
+		sensironsgp30.WithReconnectTimeout(time.Millisecond),
+		sensironsgp30.WithRecoverableErrorHandler(func(err error) bool { return false }))
+
+	group, ctx := errgroup.WithContext(ctx)
+
+	// Act
+	group.Go(func() error {
+		return sensor.Run(ctx)
+	})
+	group.Go(func() error {
+		defer cancel()
+
+		waitUntilInitialized(ctx, sensor)
+		err := sensor.HandleAbsoluteHumidity(ctx, absoluteHumidity)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-replayed:
+		}
+		time.Sleep(300 * time.Millisecond)
+		return nil
+	})
+	err := group.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+	humidity := sensor.HumidityCompensation()
+	assert.NotNil(t, humidity)
+	assert.Equal(t, absoluteHumidity, humidity.AbsoluteHumidity)
+}
@@ -0,0 +1,90 @@
+package sensironsgp30
+
+import (
+	"time"
+)
+
+const (
+	earlyOperationPhaseDuration time.Duration = 12 * time.Hour
+	warmUpDuration              time.Duration = 15 * time.Second
+)
+
+// WarmUpPolicy determines how readings taken while the sensor is warming up are handled
+type WarmUpPolicy int
+
+const (
+	// WarmUpPassThrough publishes readings taken while the sensor is warming up without distinguishing them
+	WarmUpPassThrough WarmUpPolicy = iota
+	// WarmUpDrop discards readings taken while the sensor is warming up
+	WarmUpDrop
+	// WarmUpFlag publishes readings taken while the sensor is warming up and reports the warm-up through Status
+	WarmUpFlag
+)
+
+// WithWarmUpPolicy specifies how readings taken while the sensor is warming up are handled
+func WithWarmUpPolicy(policy WarmUpPolicy) *Option {
+	return &Option{
+		apply: func(s *Sensor) {
+			s.warmUpPolicy = policy
+		},
+	}
+}
+
+// WarmUpPolicy is how readings taken while the sensor is warming up are handled
+func (s *Sensor) WarmUpPolicy() WarmUpPolicy {
+	return s.warmUpPolicy
+}
+
+// Status describes the state of the sensor's on-chip baseline algorithm
+type Status struct {
+	// InitializedAt is when the baseline algorithm was last initialized, or the zero time if it has not been initialized
+	InitializedAt time.Time
+	// BaselineRestored indicates whether a baseline was restored since the baseline algorithm was last initialized
+	BaselineRestored bool
+	// WarmingUp indicates whether the sensor is within the first 15 seconds after initialization, during which it reports fixed values, when the WarmUpFlag policy is in effect
+	WarmingUp bool
+	// EarlyOperationPhase indicates whether the sensor is within the first 12 hours of operation without a restored baseline, during which readings are less accurate
+	EarlyOperationPhase bool
+}
+
+// Status gets the current state of the sensor's on-chip baseline algorithm
+func (s *Sensor) Status() *Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.status()
+}
+
+func (s *Sensor) status() *Status {
+	initialized := !s.initializedAt.IsZero()
+	elapsed := time.Since(s.initializedAt)
+	return &Status{
+		InitializedAt:       s.initializedAt,
+		BaselineRestored:    s.baselineRestored,
+		WarmingUp:           initialized && s.warmUpPolicy == WarmUpFlag && elapsed < warmUpDuration,
+		EarlyOperationPhase: initialized && !s.baselineRestored && elapsed < earlyOperationPhaseDuration,
+	}
+}
+
+func (s *Sensor) warmingUp() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return !s.initializedAt.IsZero() && time.Since(s.initializedAt) < warmUpDuration
+}
+
+func (s *Sensor) markInitialized() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.initializedAt = time.Now()
+	s.baselineRestored = false
+	s.lastMeasurementAt = time.Time{}
+}
+
+func (s *Sensor) markBaselineRestored() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.baselineRestored = true
+}
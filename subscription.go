@@ -0,0 +1,215 @@
+package sensironsgp30
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-sensors/core/gas"
+)
+
+// OverflowPolicy determines how output is handled when a subscriber's buffer is full
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits for the subscriber to receive the output, delaying delivery to every other subscriber
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest discards the oldest buffered output to make room for the new output
+	OverflowDropOldest
+	// OverflowDropNewest discards the new output
+	OverflowDropNewest
+)
+
+// legacyBufferSize is the number of readings and the number of concentrations buffered for the shared subscription behind
+// Sensor.Readings and Sensor.Concentrations, and the number of raw signal readings buffered for Sensor.RawSignals
+const legacyBufferSize = 16
+
+// SubscriptionOptions configures the delivery of output to a subscriber
+type SubscriptionOptions struct {
+	// BufferSize is the number of readings and the number of concentrations buffered for the subscriber; negative sizes are treated as zero
+	BufferSize int
+	// OverflowPolicy is how output is handled when the subscriber's buffer is full
+	OverflowPolicy OverflowPolicy
+}
+
+// Subscription is an independent stream of the sensor's output, delivered as readings, concentrations or both
+//
+// Readings and Concentrations are views of the same measurements; each measurement is delivered to every view that has been requested.
+type Subscription struct {
+	ctx            context.Context
+	options        SubscriptionOptions
+	readings       chan *Reading
+	concentrations chan *gas.Concentration
+	dropped        uint64
+
+	mu                      sync.Mutex
+	readingsRequested       bool
+	concentrationsRequested bool
+
+	sendMu sync.Mutex
+	closed bool
+}
+
+// Subscribe creates a subscription to the sensor's output that ends, closing its channels, when the context is completed
+func (s *Sensor) Subscribe(ctx context.Context, options *SubscriptionOptions) *Subscription {
+	subscription := newSubscription(ctx, options)
+
+	s.mu.Lock()
+	s.subscriptions = append(s.subscriptions, subscription)
+	s.mu.Unlock()
+
+	if ctx.Done() != nil {
+		go func() {
+			<-ctx.Done()
+			s.unsubscribe(subscription)
+		}()
+	}
+	return subscription
+}
+
+func newSubscription(ctx context.Context, options *SubscriptionOptions) *Subscription {
+	validated := SubscriptionOptions{}
+	if options != nil {
+		validated = *options
+	}
+	if validated.BufferSize < 0 {
+		validated.BufferSize = 0
+	}
+	return &Subscription{
+		ctx:            ctx,
+		options:        validated,
+		readings:       make(chan *Reading, validated.BufferSize),
+		concentrations: make(chan *gas.Concentration, validated.BufferSize),
+	}
+}
+
+// newLegacySubscription creates the shared subscription behind Sensor.Readings and Sensor.Concentrations, which buffers
+// output for both views from the start so that measurements taken before either is first called are not lost
+func newLegacySubscription() *Subscription {
+	subscription := newSubscription(context.Background(), &SubscriptionOptions{
+		BufferSize:     legacyBufferSize,
+		OverflowPolicy: OverflowDropOldest,
+	})
+	subscription.readingsRequested = true
+	subscription.concentrationsRequested = true
+	return subscription
+}
+
+func (s *Sensor) unsubscribe(subscription *Subscription) {
+	s.mu.Lock()
+	for idx, candidate := range s.subscriptions {
+		if candidate == subscription {
+			s.subscriptions = append(s.subscriptions[:idx], s.subscriptions[idx+1:]...)
+			break
+		}
+	}
+	s.mu.Unlock()
+
+	subscription.close()
+}
+
+// Options is the configuration of the subscription
+func (sub *Subscription) Options() SubscriptionOptions {
+	return sub.options
+}
+
+// Readings returns a channel of readings as they become available from the sensor
+func (sub *Subscription) Readings() <-chan *Reading {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	sub.readingsRequested = true
+	return sub.readings
+}
+
+// Concentrations returns a channel of concentration readings as they become available from the sensor
+func (sub *Subscription) Concentrations() <-chan *gas.Concentration {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	sub.concentrationsRequested = true
+	return sub.concentrations
+}
+
+// Dropped is the number of readings and concentrations discarded because the subscriber's buffer was full
+func (sub *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&sub.dropped)
+}
+
+func (sub *Subscription) close() {
+	sub.sendMu.Lock()
+	defer sub.sendMu.Unlock()
+
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	close(sub.readings)
+	close(sub.concentrations)
+}
+
+// deliver offers published output to each of the subscription's requested views
+func (sub *Subscription) deliver(output interface{}) {
+	sub.mu.Lock()
+	readingsRequested := sub.readingsRequested
+	concentrationsRequested := sub.concentrationsRequested
+	sub.mu.Unlock()
+
+	sub.sendMu.Lock()
+	defer sub.sendMu.Unlock()
+
+	if sub.closed {
+		return
+	}
+	switch output := output.(type) {
+	case *Reading:
+		if readingsRequested {
+			offer(sub.ctx, sub.options.OverflowPolicy, &sub.dropped, sub.readings, output)
+		}
+		if concentrationsRequested {
+			for _, concentration := range output.Concentrations() {
+				offer(sub.ctx, sub.options.OverflowPolicy, &sub.dropped, sub.concentrations, concentration)
+			}
+		}
+	case *gas.Concentration:
+		if concentrationsRequested {
+			offer(sub.ctx, sub.options.OverflowPolicy, &sub.dropped, sub.concentrations, output)
+		}
+	}
+}
+
+// offer sends a value to a channel according to the overflow policy, counting any value it discards
+func offer[T any](ctx context.Context, policy OverflowPolicy, dropped *uint64, ch chan T, value T) {
+	switch policy {
+	case OverflowBlock:
+		select {
+		case <-ctx.Done():
+		case ch <- value:
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case ch <- value:
+				return
+			default:
+			}
+
+			select {
+			case <-ch:
+				atomic.AddUint64(dropped, 1)
+			default:
+				// Without a buffer there is nothing older to discard
+				if cap(ch) == 0 {
+					atomic.AddUint64(dropped, 1)
+					return
+				}
+			}
+		}
+	case OverflowDropNewest:
+		select {
+		case ch <- value:
+		default:
+			atomic.AddUint64(dropped, 1)
+		}
+	}
+}